@@ -0,0 +1,288 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package amqp
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	defaultExchangeType   string = "topic"
+	defaultConfirmTimeout int    = 5000
+
+	schemeAmqp  = "amqp"
+	schemeAmqps = "amqps"
+)
+
+// confirmChannel is a channel in confirm mode. It is implemented by amqpChannel and replaced in tests.
+type confirmChannel interface {
+	// publish sends the message and waits for the broker's confirm, reporting whether it was an ack
+	publish(ctx context.Context, exchange, key string, mandatory bool, msg amqp.Publishing) (bool, error)
+	// returns receives the messages the broker could not route
+	returns() <-chan amqp.Return
+	IsClosed() bool
+	Close() error
+}
+
+type amqpPublisher struct {
+	cfg     config.AmqpConfig
+	logger  interfaces.Logger
+	uri     string
+	tls     *tls.Config
+	mutex   sync.Mutex // serializes publishes so that returns and confirms can be attributed to the current message
+	conn    *amqp.Connection
+	channel confirmChannel
+	open    func() (confirmChannel, error) // replaced in tests
+}
+
+// NewAmqpPublisher validates the configuration and prepares a publisher. No connection to the broker is made
+// until Connect is called.
+func NewAmqpPublisher(cfg config.AmqpConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if len(cfg.RoutingKeys) == 0 {
+		return nil, errors.New("at least one AMQP routing key must be provided")
+	}
+	if cfg.ExchangeType == "" {
+		cfg.ExchangeType = defaultExchangeType
+	}
+	if cfg.ConfirmTimeout == 0 {
+		cfg.ConfirmTimeout = defaultConfirmTimeout
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+
+	// The client only negotiates TLS for the amqps scheme
+	scheme := cfg.Provider.Protocol
+	if scheme == "" {
+		scheme = schemeAmqp
+		if tlsCfg != nil {
+			scheme = schemeAmqps
+		}
+	}
+	if scheme != schemeAmqp && scheme != schemeAmqps {
+		return nil, fmt.Errorf("invalid AMQP protocol %s, expected %s or %s", scheme, schemeAmqp, schemeAmqps)
+	}
+	if scheme == schemeAmqp && tlsCfg != nil {
+		return nil, fmt.Errorf("AMQP protocol %s cannot be used with TLS, use %s", schemeAmqp, schemeAmqps)
+	}
+
+	u := url.URL{
+		Scheme: scheme,
+		Host:   cfg.Provider.Address(),
+		Path:   "/" + cfg.VHost,
+	}
+	if cfg.User != "" {
+		u.User = url.UserPassword(cfg.User, cfg.Password)
+	}
+
+	p := amqpPublisher{
+		cfg:    cfg,
+		logger: logger,
+		uri:    u.String(),
+		tls:    tlsCfg,
+	}
+	p.open = p.openChannel
+	return &p, nil
+}
+
+func (p *amqpPublisher) Connect() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	// It would be highly odd if the publisher were to be already connected here, but check anyway
+	return p.reconnect()
+}
+
+// Publish sends the message once per configured routing key and waits for each publisher confirm. A nack, an
+// unroutable message when Mandatory is set, or a confirm timeout are all reported as errors.
+func (p *amqpPublisher) Publish(msg message.PublishWrapper) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Verify connectivity first. If it's been dropped, this will attempt one reconnect before publish
+	err := p.reconnect()
+	if err != nil {
+		return err
+	}
+
+	b, _ := json.Marshal(msg)
+	publishing := amqp.Publishing{
+		ContentType: string(contracts.ContentTypeJSON),
+		MessageId:   msg.MessageId(),
+		Type:        msg.MessageType,
+		Timestamp:   time.Now(),
+		Body:        b,
+	}
+	if p.cfg.Persistent {
+		publishing.DeliveryMode = amqp.Persistent
+	}
+
+	var errs []error
+	for _, key := range p.cfg.RoutingKeys {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, routing key %s %s", key, string(b)))
+		err = p.publish(key, publishing)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("routing key %s: %w", key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *amqpPublisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.channel != nil && !p.channel.IsClosed() {
+		_ = p.channel.Close()
+	}
+	if p.conn == nil || p.conn.IsClosed() {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+func (p *amqpPublisher) publish(key string, publishing amqp.Publishing) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.ConfirmTimeout))
+	defer cancel()
+
+	// Returns of earlier messages which were nacked or timed out must not be attributed to this one
+	p.drainReturns(func(amqp.Return) bool { return false })
+
+	acked, err := p.channel.publish(ctx, p.cfg.Exchange, key, p.cfg.Mandatory, publishing)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errors.New("message was nacked by the broker")
+	}
+
+	// The broker sends basic.return ahead of the confirm, so an unroutable message is already waiting here
+	var returned *amqp.Return
+	p.drainReturns(func(r amqp.Return) bool {
+		if r.MessageId == publishing.MessageId && r.RoutingKey == key {
+			returned = &r
+			return true
+		}
+		return false
+	})
+	if returned != nil {
+		return fmt.Errorf("message returned by the broker: %d %s", returned.ReplyCode, returned.ReplyText)
+	}
+	return nil
+}
+
+// drainReturns consumes the returns waiting on the channel until match reports the one sought, discarding the
+// others with a warning.
+func (p *amqpPublisher) drainReturns(match func(amqp.Return) bool) {
+	for {
+		select {
+		case r := <-p.channel.returns():
+			if match(r) {
+				return
+			}
+			p.logger.Write(slog.LevelWarn, fmt.Sprintf("discarding return of an earlier message %s, routing key %s: %d %s",
+				r.MessageId, r.RoutingKey, r.ReplyCode, r.ReplyText))
+		default:
+			return
+		}
+	}
+}
+
+// reconnect opens a channel in confirm mode, dialing the broker first if needed, if the current one has been
+// closed. Must be called with the mutex held.
+func (p *amqpPublisher) reconnect() error {
+	if p.channel != nil && !p.channel.IsClosed() {
+		return nil
+	}
+	channel, err := p.open()
+	if err != nil {
+		return err
+	}
+	p.channel = channel
+	return nil
+}
+
+func (p *amqpPublisher) openChannel() (confirmChannel, error) {
+	if p.conn == nil || p.conn.IsClosed() {
+		var conn *amqp.Connection
+		var err error
+		if p.tls != nil {
+			conn, err = amqp.DialTLS(p.uri, p.tls)
+		} else {
+			conn, err = amqp.Dial(p.uri)
+		}
+		if err != nil {
+			return nil, err
+		}
+		p.conn = conn
+	}
+
+	channel, err := p.conn.Channel()
+	if err != nil {
+		return nil, err
+	}
+	err = channel.Confirm(false)
+	if err != nil {
+		_ = channel.Close()
+		return nil, err
+	}
+	if p.cfg.DeclareExchange {
+		err = channel.ExchangeDeclare(p.cfg.Exchange, p.cfg.ExchangeType, true, false, false, false, nil)
+		if err != nil {
+			_ = channel.Close()
+			return nil, err
+		}
+	}
+	returned := channel.NotifyReturn(make(chan amqp.Return, len(p.cfg.RoutingKeys)))
+	return &amqpChannel{Channel: channel, returned: returned}, nil
+}
+
+// amqpChannel implements confirmChannel with a channel of the broker connection.
+type amqpChannel struct {
+	*amqp.Channel
+	returned chan amqp.Return
+}
+
+func (c *amqpChannel) publish(ctx context.Context, exchange, key string, mandatory bool, msg amqp.Publishing) (bool, error) {
+	confirm, err := c.PublishWithDeferredConfirmWithContext(ctx, exchange, key, mandatory, false, msg)
+	if err != nil {
+		return false, err
+	}
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return false, fmt.Errorf("no publisher confirm received: %w", err)
+	}
+	return acked, nil
+}
+
+func (c *amqpChannel) returns() <-chan amqp.Return {
+	return c.returned
+}
@@ -0,0 +1,209 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package amqp
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// fakeChannel stands in for a channel in confirm mode, answering each publish with the next result.
+type fakeChannel struct {
+	results   []fakeResult
+	returned  chan amqp.Return
+	published []string // routing keys
+	closed    bool
+}
+
+type fakeResult struct {
+	acked    bool
+	err      error
+	returned bool // the broker returns the message as unroutable before confirming it
+}
+
+func newFakeChannel(results ...fakeResult) *fakeChannel {
+	return &fakeChannel{results: results, returned: make(chan amqp.Return, len(results))}
+}
+
+func (c *fakeChannel) publish(_ context.Context, _, key string, mandatory bool, msg amqp.Publishing) (bool, error) {
+	c.published = append(c.published, key)
+	r := fakeResult{acked: true}
+	if len(c.results) > 0 {
+		r = c.results[0]
+		c.results = c.results[1:]
+	}
+	if r.returned && mandatory {
+		c.returned <- amqp.Return{ReplyCode: amqp.NoRoute, ReplyText: "NO_ROUTE", RoutingKey: key, MessageId: msg.MessageId}
+	}
+	return r.acked, r.err
+}
+
+func (c *fakeChannel) returns() <-chan amqp.Return {
+	return c.returned
+}
+
+func (c *fakeChannel) IsClosed() bool {
+	return c.closed
+}
+
+func (c *fakeChannel) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestNewAmqpPublisherScheme(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	tests := []struct {
+		name         string
+		protocol     string
+		tls          config.TlsInfo
+		expectScheme string
+		expectError  bool
+	}{
+		{"default", "", config.TlsInfo{}, "amqp://", false},
+		{"default with tls", "", config.TlsInfo{Enabled: true}, "amqps://", false},
+		{"explicit amqps", "amqps", config.TlsInfo{}, "amqps://", false},
+		{"amqp with tls", "amqp", config.TlsInfo{Enabled: true}, "", true},
+		{"unsupported", "tcp", config.TlsInfo{}, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.AmqpConfig{
+				Provider:    config.ServiceInfo{Host: "localhost", Port: 5672, Protocol: tt.protocol},
+				RoutingKeys: []string{"annotations"},
+				Tls:         tt.tls,
+			}
+			p, err := NewAmqpPublisher(cfg, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			if uri := p.(*amqpPublisher).uri; !strings.HasPrefix(uri, tt.expectScheme) {
+				t.Errorf("unexpected uri %s", uri)
+			}
+		})
+	}
+}
+
+func TestAmqpPublisherConfirms(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	timeout := errors.New("no publisher confirm received: context deadline exceeded")
+
+	tests := []struct {
+		name         string
+		mandatory    bool
+		results      []fakeResult
+		expectError  bool
+		expectFailed []string // routing keys reported in the error
+	}{
+		{"acked", false, nil, false, nil},
+		{"nacked", false, []fakeResult{{acked: false}, {acked: true}}, true, []string{"key1"}},
+		{"returned when mandatory", true, []fakeResult{{acked: true}, {acked: true, returned: true}}, true, []string{"key2"}},
+		{"returned ignored unless mandatory", false, []fakeResult{{acked: true, returned: true}}, false, nil},
+		{"confirm timeout", false, []fakeResult{{err: timeout}, {err: timeout}}, true, []string{"key1", "key2"}},
+		{"stale return after nack", true, []fakeResult{{acked: false, returned: true}, {acked: true}}, true, []string{"key1"}},
+		{"stale return after timeout", true, []fakeResult{{err: timeout, returned: true}, {acked: true}}, true, []string{"key1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.AmqpConfig{
+				Provider:    config.ServiceInfo{Host: "localhost", Port: 5672},
+				RoutingKeys: []string{"key1", "key2"},
+				Mandatory:   tt.mandatory,
+			}
+			p, err := NewAmqpPublisher(cfg, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			channel := newFakeChannel(tt.results...)
+			p.(*amqpPublisher).open = func() (confirmChannel, error) {
+				return channel, nil
+			}
+			if err = p.Connect(); err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			err = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if len(channel.published) != len(cfg.RoutingKeys) {
+				t.Errorf("expected a publish per routing key, received %v", channel.published)
+			}
+			for _, key := range cfg.RoutingKeys {
+				expected := strings.Contains(strings.Join(tt.expectFailed, ","), key)
+				if (err != nil && strings.Contains(err.Error(), "routing key "+key)) != expected {
+					t.Errorf("unexpected report of routing key %s in %v", key, err)
+				}
+			}
+
+			if err = p.Close(); err != nil || !channel.closed {
+				t.Errorf("expected channel to be closed, %v", err)
+			}
+		})
+	}
+}
+
+func TestAmqpPublisherReconnect(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.AmqpConfig{Provider: config.ServiceInfo{Host: "localhost", Port: 5672}, RoutingKeys: []string{"key"}}
+	p, err := NewAmqpPublisher(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	var opened []*fakeChannel
+	var openErr error
+	p.(*amqpPublisher).open = func() (confirmChannel, error) {
+		if openErr != nil {
+			return nil, openErr
+		}
+		c := newFakeChannel()
+		opened = append(opened, c)
+		return c, nil
+	}
+	if err = p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	msg := message.PublishWrapper{Action: message.ActionCreate}
+	if err = p.Publish(msg); err != nil || len(opened) != 1 {
+		t.Fatalf("unexpected error %v with %d channels opened", err, len(opened))
+	}
+
+	// The broker closes the channel, so the next publish reopens it
+	opened[0].closed = true
+	if err = p.Publish(msg); err != nil || len(opened) != 2 || len(opened[1].published) != 1 {
+		t.Fatalf("expected publish on a new channel, error %v with %d channels opened", err, len(opened))
+	}
+
+	// While the broker is unreachable publishes fail until it is back
+	opened[1].closed = true
+	openErr = errors.New("connection refused")
+	if err = p.Publish(msg); err == nil {
+		t.Errorf("expected publish to fail while the broker is unreachable")
+	}
+	openErr = nil
+	if err = p.Publish(msg); err != nil || len(opened) != 3 {
+		t.Errorf("expected publish to recover, error %v with %d channels opened", err, len(opened))
+	}
+}
@@ -0,0 +1,62 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// TempExt is appended to the path of a file being written. A file with this suffix left behind by a crash holds an
+// incomplete write and can be removed.
+const TempExt = ".tmp"
+
+// Write replaces the file at path with data. The data is written and synced to a temporary file which is then renamed
+// into place, and the directory is synced so that the rename itself survives a crash. Readers therefore see either
+// the previous content or the new one, never a partial write.
+func Write(path string, data []byte, perm os.FileMode) error {
+	tmp := path + TempExt
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	err = os.Rename(tmp, path)
+	if err != nil {
+		return err
+	}
+	return SyncDir(filepath.Dir(path))
+}
+
+// SyncDir flushes the directory entry changes made in path, such as created, renamed or removed files.
+func SyncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
@@ -0,0 +1,54 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package atomicfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestWrite(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name        string
+		path        string
+		data        string
+		expectError bool
+	}{
+		{"create", filepath.Join(dir, "state.json"), `{"a":1}`, false},
+		{"replace", filepath.Join(dir, "state.json"), `{"b":2}`, false},
+		{"missing directory", filepath.Join(dir, "missing", "state.json"), `{}`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Write(tt.path, []byte(tt.data), 0600)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			b, err := os.ReadFile(tt.path)
+			if err != nil || string(b) != tt.data {
+				t.Errorf("unexpected content %s %v", string(b), err)
+			}
+			if _, err = os.Stat(tt.path + TempExt); !os.IsNotExist(err) {
+				t.Errorf("expected temporary file to be renamed, received %v", err)
+			}
+		})
+	}
+}
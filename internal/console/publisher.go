@@ -1,19 +1,89 @@
 package console
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/annotators"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
 )
 
+const (
+	signatureValid      = "valid"
+	signatureInvalid    = "invalid"
+	signatureUnverified = "unverified"
+)
+
 type consolePublisher struct {
-	logger interfaces.Logger
+	cfg       config.ConsoleConfig
+	signature interfaces.SignatureProvider // nil unless a public key is configured
+	logger    interfaces.Logger
+
+	mutex sync.Mutex // keeps the output of concurrent publishes apart
+	out   io.Writer
+}
+
+// printed is the representation of a message in the JSON formats
+type printed struct {
+	Action      message.SdkAction   `json:"action,omitempty"`
+	MessageType string              `json:"messageType,omitempty"`
+	Content     interface{}         `json:"content,omitempty"` // Embedded as is when it is JSON, otherwise as a string
+	Annotations []printedAnnotation `json:"annotations,omitempty"`
 }
 
-func NewConsolePublisher(logger interfaces.Logger) interfaces.StreamProvider {
-	return &consolePublisher{
-		logger: logger,
+type printedAnnotation struct {
+	Id          string                   `json:"id"`
+	Key         string                   `json:"key"`
+	Kind        contracts.AnnotationType `json:"kind"`
+	Layer       contracts.LayerType      `json:"layer,omitempty"`
+	Host        string                   `json:"host,omitempty"`
+	Tag         string                   `json:"tag,omitempty"`
+	IsSatisfied bool                     `json:"isSatisfied"`
+	Signature   string                   `json:"signature"` // One of "valid", "invalid" or "unverified"
+	Timestamp   time.Time                `json:"timestamp"`
+}
+
+// NewConsolePublisher prints messages in the configured format. The signature provider is used to verify expanded
+// annotations and is required when a public key is configured.
+func NewConsolePublisher(cfg config.ConsoleConfig, signature interfaces.SignatureProvider,
+	logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.Format == "" {
+		cfg.Format = contracts.ConsoleText
+	}
+	if cfg.Output == "" {
+		cfg.Output = contracts.ConsoleStdout
+	}
+	if !cfg.Format.Validate() {
+		return nil, fmt.Errorf("invalid ConsoleFormat value provided %s", cfg.Format)
+	}
+	if cfg.PublicKey != nil && signature == nil {
+		return nil, fmt.Errorf("signature provider must be provided to verify annotations")
 	}
+
+	p := consolePublisher{
+		cfg:       cfg,
+		signature: signature,
+		logger:    logger,
+	}
+	switch cfg.Output {
+	case contracts.ConsoleStdout:
+		p.out = os.Stdout
+	case contracts.ConsoleStderr:
+		p.out = os.Stderr
+	default:
+		return nil, fmt.Errorf("invalid ConsoleOutput value provided %s", cfg.Output)
+	}
+	return &p, nil
 }
 
 func (p *consolePublisher) Connect() error {
@@ -21,10 +91,121 @@ func (p *consolePublisher) Connect() error {
 }
 
 func (p *consolePublisher) Publish(msg message.PublishWrapper) error {
-	fmt.Printf("action: %s, messageType: %s, %v\n", msg.Action, msg.MessageType, string(msg.Content))
-	return nil
+	var b []byte
+	var err error
+	switch p.cfg.Format {
+	case contracts.ConsoleTable:
+		b = p.table(msg)
+	case contracts.ConsoleJson:
+		b, err = json.MarshalIndent(p.printed(msg), "", "  ")
+		b = append(b, '\n')
+	case contracts.ConsoleNdjson:
+		b, err = json.Marshal(p.printed(msg))
+		b = append(b, '\n')
+	default:
+		b = p.text(msg)
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	_, err = p.out.Write(b)
+	return err
 }
 
 func (p *consolePublisher) Close() error {
 	return nil
 }
+
+func (p *consolePublisher) text(msg message.PublishWrapper) []byte {
+	list, ok := p.expand(msg)
+	if !ok {
+		return []byte(fmt.Sprintf("action: %s, messageType: %s, %v\n", msg.Action, msg.MessageType, string(msg.Content)))
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "action: %s, messageType: %s, annotations: %d\n", msg.Action, msg.MessageType, len(list))
+	for _, a := range list {
+		fmt.Fprintf(&buf, "  id: %s, kind: %s, satisfied: %t, signature: %s, host: %s, key: %s\n",
+			a.Id, a.Kind, a.IsSatisfied, a.Signature, a.Host, a.Key)
+	}
+	return buf.Bytes()
+}
+
+// table prints a row per annotation if expanded, otherwise a single row for the message
+func (p *consolePublisher) table(msg message.PublishWrapper) []byte {
+	var buf bytes.Buffer
+	w := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	list, ok := p.expand(msg)
+	if !ok {
+		fmt.Fprintln(w, "ACTION\tMESSAGE TYPE\tCONTENT")
+		fmt.Fprintf(w, "%s\t%s\t%s\n", msg.Action, msg.MessageType, string(msg.Content))
+	} else {
+		fmt.Fprintln(w, "ACTION\tID\tKIND\tSATISFIED\tSIGNATURE\tLAYER\tHOST\tKEY")
+		for _, a := range list {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", msg.Action, a.Id, a.Kind, strconv.FormatBool(a.IsSatisfied),
+				a.Signature, a.Layer, a.Host, a.Key)
+		}
+	}
+	_ = w.Flush()
+	buf.WriteByte('\n')
+	return buf.Bytes()
+}
+
+func (p *consolePublisher) printed(msg message.PublishWrapper) printed {
+	out := printed{Action: msg.Action, MessageType: msg.MessageType}
+	if list, ok := p.expand(msg); ok {
+		out.Annotations = list
+		return out
+	}
+	if json.Valid(msg.Content) {
+		out.Content = json.RawMessage(msg.Content)
+	} else if len(msg.Content) > 0 {
+		out.Content = string(msg.Content)
+	}
+	return out
+}
+
+// expand decodes the annotations carried by the message if configured
+func (p *consolePublisher) expand(msg message.PublishWrapper) ([]printedAnnotation, bool) {
+	if !p.cfg.ExpandAnnotations {
+		return nil, false
+	}
+	list, ok := msg.AnnotationList()
+	if !ok {
+		return nil, false
+	}
+
+	items := make([]printedAnnotation, len(list.Items))
+	for i, a := range list.Items {
+		items[i] = printedAnnotation{
+			Id:          a.Id.String(),
+			Key:         a.Key,
+			Kind:        a.Kind,
+			Layer:       a.Layer,
+			Host:        a.Host,
+			Tag:         a.Tag,
+			IsSatisfied: a.IsSatisfied,
+			Signature:   p.verify(a),
+			Timestamp:   a.Timestamp,
+		}
+	}
+	return items, true
+}
+
+func (p *consolePublisher) verify(a contracts.Annotation) string {
+	if p.cfg.PublicKey == nil {
+		return signatureUnverified
+	}
+	ok, err := annotators.VerifySignature(*p.cfg.PublicKey, p.signature, a)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed to verify signature of annotation %s: %s", a.Id.String(), err.Error()))
+		return signatureUnverified
+	}
+	if !ok {
+		return signatureInvalid
+	}
+	return signatureValid
+}
@@ -0,0 +1,153 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package console
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/annotators"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/signprovider/ed25519"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// signedWrapper carries a correctly signed annotation followed by one altered after signing
+func signedWrapper(t *testing.T) message.PublishWrapper {
+	key := config.KeyInfo{Type: contracts.KeyEd25519, Path: "../../test/keys/ed25519/private.key"}
+	list := contracts.AnnotationList{}
+	for _, kind := range []contracts.AnnotationType{contracts.AnnotationTPM, contracts.AnnotationPKI} {
+		a := contracts.NewAnnotation("key", contracts.SHA256Hash, "host", contracts.Host, kind, true)
+		signature, err := annotators.SignAnnotation(key, ed25519.New(), a)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		a.Signature = signature
+		list.Items = append(list.Items, a)
+	}
+	list.Items[1].IsSatisfied = false
+
+	b, _ := json.Marshal(list)
+	return message.PublishWrapper{Action: message.ActionCreate, MessageType: fmt.Sprintf("%T", list), Content: b}
+}
+
+func TestConsolePublisher(t *testing.T) {
+	publicKey := &config.KeyInfo{Type: contracts.KeyEd25519, Path: "../../test/keys/ed25519/public.key"}
+	broadcast := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("0.0.2001")}
+
+	tests := []struct {
+		name        string
+		cfg         config.ConsoleConfig
+		verify      bool
+		expectLines []string // expected in order within the output
+		expectError bool
+	}{
+		{"default text", config.ConsoleConfig{}, false,
+			[]string{"action: create, messageType: contracts.AnnotationList, {\"items\":", "action: broadcast, messageType: string, 0.0.2001"}, false},
+		{"expanded text", config.ConsoleConfig{ExpandAnnotations: true, PublicKey: publicKey}, true,
+			[]string{"annotations: 2", "kind: tpm, satisfied: true, signature: valid", "kind: pki, satisfied: false, signature: invalid"}, false},
+		{"table", config.ConsoleConfig{Format: contracts.ConsoleTable}, false,
+			[]string{"ACTION  MESSAGE TYPE", "create  contracts.AnnotationList", "broadcast  string"}, false},
+		{"expanded table", config.ConsoleConfig{Format: contracts.ConsoleTable, ExpandAnnotations: true}, false,
+			[]string{"ACTION  ID", "SIGNATURE", "tpm   true       unverified", "pki   false      unverified"}, false},
+		{"json", config.ConsoleConfig{Format: contracts.ConsoleJson, ExpandAnnotations: true}, false,
+			[]string{"{", "  \"action\": \"create\",", "\"kind\": \"tpm\"", "\"content\": \"0.0.2001\""}, false},
+		{"invalid format", config.ConsoleConfig{Format: "xml"}, false, nil, true},
+		{"invalid output", config.ConsoleConfig{Output: "printer"}, false, nil, true},
+		{"missing signature provider", config.ConsoleConfig{PublicKey: publicKey}, false, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+			var signature interfaces.SignatureProvider
+			if tt.verify {
+				signature = ed25519.New()
+			}
+			provider, err := NewConsolePublisher(tt.cfg, signature, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			p := provider.(*consolePublisher)
+			var out bytes.Buffer
+			p.out = &out
+
+			for _, msg := range []message.PublishWrapper{signedWrapper(t), broadcast} {
+				err = p.Publish(msg)
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+
+			remaining := out.String()
+			for _, line := range tt.expectLines {
+				i := strings.Index(remaining, line)
+				if i < 0 {
+					t.Fatalf("expected %q in output\n%s", line, out.String())
+				}
+				remaining = remaining[i+len(line):]
+			}
+		})
+	}
+}
+
+func TestConsolePublisherNdjson(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.ConsoleConfig{Format: contracts.ConsoleNdjson, Output: contracts.ConsoleStderr, ExpandAnnotations: true}
+	provider, err := NewConsolePublisher(cfg, nil, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	p := provider.(*consolePublisher)
+	var out bytes.Buffer
+	p.out = &out
+
+	msg := signedWrapper(t)
+	err = p.Publish(msg)
+	if err == nil {
+		err = p.Publish(msg)
+	}
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	scanner := bufio.NewScanner(&out)
+	lines := 0
+	for scanner.Scan() {
+		lines++
+		var decoded printed
+		err = json.Unmarshal(scanner.Bytes(), &decoded)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if decoded.Action != message.ActionCreate || len(decoded.Annotations) != 2 ||
+			decoded.Annotations[0].Kind != contracts.AnnotationTPM || decoded.Annotations[1].IsSatisfied ||
+			decoded.Annotations[0].Signature != signatureUnverified {
+			t.Errorf("unexpected line %s", scanner.Text())
+		}
+	}
+	if lines != 2 {
+		t.Errorf("expected 2 lines, received %d", lines)
+	}
+}
@@ -0,0 +1,403 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package file
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultFsyncInterval int = 1000
+
+	compressedExt   = ".gz"
+	rotatedTimeFmt  = "20060102T150405.000000"
+	rotatedTempFile = ".tmp"
+)
+
+type filePublisher struct {
+	cfg    config.FileConfig
+	logger interfaces.Logger
+
+	mutex   sync.Mutex // guards file, size, opened, last and rotated
+	file    *os.File
+	size    int64
+	opened  time.Time
+	last    time.Time     // time of the most recent rotation, keeps segment names unique
+	rotated []string      // segments awaiting compression and pruning
+	wake    chan struct{} // signalled when segments are added to rotated
+	stop    chan struct{}
+	workers sync.WaitGroup
+}
+
+// NewFilePublisher validates the configuration and prepares a publisher. The file is not opened until Connect is
+// called.
+func NewFilePublisher(cfg config.FileConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file stream path must be provided")
+	}
+	if cfg.Fsync == "" {
+		cfg.Fsync = contracts.FsyncNever
+	}
+	if cfg.FsyncInterval == 0 {
+		cfg.FsyncInterval = defaultFsyncInterval
+	}
+
+	p := filePublisher{
+		cfg:    cfg,
+		logger: logger,
+	}
+	return &p, nil
+}
+
+// Connect opens the file for appending, creating it and its directory if necessary, and starts the background
+// work for rotated segments and interval syncing.
+func (p *filePublisher) Connect() error {
+	err := os.MkdirAll(filepath.Dir(p.cfg.Path), 0700)
+	if err != nil {
+		return err
+	}
+
+	// Compress segments left behind if the process previously exited before getting to them
+	var leftover []string
+	if p.cfg.Compress {
+		segments, err := listSegments(p.cfg.Path)
+		if err != nil {
+			return err
+		}
+		for _, s := range segments {
+			if !strings.HasSuffix(s, compressedExt) {
+				leftover = append(leftover, s)
+			}
+		}
+	}
+
+	p.mutex.Lock()
+	err = p.open()
+	p.rotated = leftover
+	p.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.wake = make(chan struct{}, 1)
+	p.stop = make(chan struct{})
+	p.workers.Add(1)
+	go p.archive()
+	if p.cfg.Fsync == contracts.FsyncInterval {
+		p.workers.Add(1)
+		go p.sync()
+	}
+	return nil
+}
+
+// Publish appends the message as a single line, rotating the file first if the configured size or age would
+// otherwise be exceeded.
+func (p *filePublisher) Publish(msg message.PublishWrapper) error {
+	b, _ := json.Marshal(msg)
+	b = append(b, '\n')
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.file == nil {
+		return errors.New("file publisher is not connected")
+	}
+	p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, file %s %s", p.cfg.Path, string(b)))
+
+	if p.due(int64(len(b))) {
+		err := p.rotate()
+		if err != nil {
+			return fmt.Errorf("failed to rotate %s: %w", p.cfg.Path, err)
+		}
+	}
+
+	n, err := p.file.Write(b)
+	p.size += int64(n)
+	if err != nil {
+		return err
+	}
+	if p.cfg.Fsync == contracts.FsyncAlways {
+		return p.file.Sync()
+	}
+	return nil
+}
+
+// Close syncs and closes the file, then waits for any pending compression to complete.
+func (p *filePublisher) Close() error {
+	p.mutex.Lock()
+	if p.file == nil {
+		p.mutex.Unlock()
+		return nil
+	}
+	err := p.file.Sync()
+	if closeErr := p.file.Close(); err == nil {
+		err = closeErr
+	}
+	p.file = nil
+	close(p.stop)
+	p.mutex.Unlock()
+
+	p.workers.Wait()
+	return err
+}
+
+// open must be called with the mutex held.
+func (p *filePublisher) open() error {
+	f, err := os.OpenFile(p.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	p.file = f
+	p.size = info.Size()
+	p.opened = time.Now()
+	return nil
+}
+
+// due reports whether the file must be rotated before writing the given number of bytes. An empty file is never
+// rotated so that a single oversized message still gets written.
+func (p *filePublisher) due(n int64) bool {
+	if p.size == 0 {
+		return false
+	}
+	if p.cfg.MaxSize > 0 && p.size+n > p.cfg.MaxSize {
+		return true
+	}
+	if p.cfg.RotateInterval > 0 && time.Since(p.opened) >= time.Millisecond*time.Duration(p.cfg.RotateInterval) {
+		return true
+	}
+	return false
+}
+
+// rotate moves the active file aside and opens a new one. Must be called with the mutex held.
+func (p *filePublisher) rotate() error {
+	err := p.file.Sync()
+	if err != nil {
+		return err
+	}
+	err = p.file.Close()
+	if err != nil {
+		return err
+	}
+	p.file = nil
+
+	dir, stem, ext := nameParts(p.cfg.Path)
+	now := time.Now().UTC().Truncate(time.Microsecond)
+	if !now.After(p.last) {
+		now = p.last.Add(time.Microsecond)
+	}
+	p.last = now
+	segment := filepath.Join(dir, fmt.Sprintf("%s-%s%s", stem, now.Format(rotatedTimeFmt), ext))
+	err = os.Rename(p.cfg.Path, segment)
+	if err != nil {
+		// Keep writing to the existing file rather than losing messages
+		if openErr := p.open(); openErr != nil {
+			return errors.Join(err, openErr)
+		}
+		return err
+	}
+	p.logger.Write(slog.LevelDebug, fmt.Sprintf("rotated %s to %s", p.cfg.Path, segment))
+
+	err = p.open()
+	if err != nil {
+		return err
+	}
+	// Never wait on the archiving worker, which may be busy compressing, while holding the mutex
+	p.rotated = append(p.rotated, segment)
+	select {
+	case p.wake <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// archive compresses rotated segments if configured and removes those exceeding MaxBackups. Segments rotated before
+// the publisher is closed are still archived.
+func (p *filePublisher) archive() {
+	defer p.workers.Done()
+
+	for {
+		select {
+		case <-p.wake:
+			p.archivePending()
+		case <-p.stop:
+			p.archivePending()
+			return
+		}
+	}
+}
+
+func (p *filePublisher) archivePending() {
+	p.mutex.Lock()
+	segments := p.rotated
+	p.rotated = nil
+	p.mutex.Unlock()
+
+	for _, segment := range segments {
+		if p.cfg.Compress {
+			err := compress(segment)
+			if err != nil {
+				p.logger.Error(fmt.Sprintf("failed to compress %s: %s", segment, err.Error()))
+			}
+		}
+		if p.cfg.MaxBackups > 0 {
+			err := p.prune()
+			if err != nil {
+				p.logger.Error(fmt.Sprintf("failed to remove old segments of %s: %s", p.cfg.Path, err.Error()))
+			}
+		}
+	}
+}
+
+func (p *filePublisher) sync() {
+	defer p.workers.Done()
+
+	ticker := time.NewTicker(time.Millisecond * time.Duration(p.cfg.FsyncInterval))
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.stop:
+			return
+		case <-ticker.C:
+			p.mutex.Lock()
+			if p.file != nil {
+				err := p.file.Sync()
+				if err != nil {
+					p.logger.Error(fmt.Sprintf("failed to sync %s: %s", p.cfg.Path, err.Error()))
+				}
+			}
+			p.mutex.Unlock()
+		}
+	}
+}
+
+func (p *filePublisher) prune() error {
+	segments, err := listSegments(p.cfg.Path)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for len(segments) > p.cfg.MaxBackups {
+		err = os.Remove(segments[0])
+		if err != nil {
+			errs = append(errs, err)
+		}
+		segments = segments[1:]
+	}
+	return errors.Join(errs...)
+}
+
+// listSegments returns the paths of all rotated segments of the file at path, oldest first. Other files in the
+// directory are left out, even if their names start with that of the file.
+func listSegments(path string) ([]string, error) {
+	dir, _, _ := nameParts(path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		if _, ok := rotationTime(path, e.Name()); ok {
+			segments = append(segments, filepath.Join(dir, e.Name()))
+		}
+	}
+	// The timestamp embedded in the name sorts chronologically
+	sort.Strings(segments)
+	return segments, nil
+}
+
+// rotationTime returns the time embedded in the base name of a rotated segment of the file at path, compressed or
+// not. It returns false if name is not that of a segment.
+func rotationTime(path string, name string) (time.Time, bool) {
+	_, stem, ext := nameParts(path)
+	ts, ok := strings.CutPrefix(strings.TrimSuffix(name, compressedExt), stem+"-")
+	if !ok {
+		return time.Time{}, false
+	}
+	ts, ok = strings.CutSuffix(ts, ext)
+	if !ok || len(ts) != len(rotatedTimeFmt) {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(rotatedTimeFmt, ts)
+	return t, err == nil
+}
+
+func nameParts(path string) (dir string, stem string, ext string) {
+	dir = filepath.Dir(path)
+	base := filepath.Base(path)
+	ext = filepath.Ext(base)
+	return dir, strings.TrimSuffix(base, ext), ext
+}
+
+// compress replaces the segment with a gzip compressed copy. The copy is synced and renamed into place before the
+// original is removed so that a crash never loses the segment.
+func compress(segment string) error {
+	in, err := os.Open(segment)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	tmp := segment + compressedExt + rotatedTempFile
+	out, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(out)
+	_, err = io.Copy(zw, in)
+	if err == nil {
+		err = zw.Close()
+	}
+	if err == nil {
+		err = out.Sync()
+	}
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	err = os.Rename(tmp, segment+compressedExt)
+	if err != nil {
+		return err
+	}
+	return os.Remove(segment)
+}
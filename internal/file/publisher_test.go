@@ -0,0 +1,156 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package file
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+func TestFilePublisherRotation(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	msg := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+	b, _ := json.Marshal(msg)
+	line := int64(len(b) + 1)
+
+	tests := []struct {
+		name           string
+		cfg            config.FileConfig
+		publish        int
+		expectSegments int
+		expectLines    int // total across the active file and all segments
+	}{
+		{"no rotation", config.FileConfig{Fsync: contracts.FsyncAlways}, 5, 0, 5},
+		{"rotate by size", config.FileConfig{MaxSize: line * 2}, 5, 2, 5},
+		{"rotate by size compressed", config.FileConfig{MaxSize: line * 2, Compress: true}, 5, 2, 5},
+		{"rotate by size pruned", config.FileConfig{MaxSize: line, MaxBackups: 2}, 5, 2, 3},
+		{"rotate by time", config.FileConfig{RotateInterval: 1}, 3, 2, 3},
+		{"interval sync", config.FileConfig{Fsync: contracts.FsyncInterval, FsyncInterval: 1}, 3, 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.cfg.Path = filepath.Join(t.TempDir(), "annotations.jsonl")
+			p, err := NewFilePublisher(tt.cfg, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			err = p.Connect()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			for i := 0; i < tt.publish; i++ {
+				if tt.cfg.RotateInterval > 0 {
+					time.Sleep(time.Millisecond * 2)
+				}
+				err = p.Publish(msg)
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			err = p.Close()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			segments, _ := filepath.Glob(filepath.Join(filepath.Dir(tt.cfg.Path), "annotations-*"))
+			if len(segments) != tt.expectSegments {
+				t.Errorf("expected %d segments, got %v", tt.expectSegments, segments)
+			}
+
+			lines := readLines(t, tt.cfg.Path)
+			for _, s := range segments {
+				if tt.cfg.Compress != strings.HasSuffix(s, ".gz") {
+					t.Errorf("unexpected segment name %s", s)
+				}
+				lines = append(lines, readLines(t, s)...)
+			}
+			if len(lines) != tt.expectLines {
+				t.Errorf("expected %d lines, got %d", tt.expectLines, len(lines))
+			}
+			for _, l := range lines {
+				var w message.PublishWrapper
+				err = json.Unmarshal([]byte(l), &w)
+				if err != nil || w.Action != msg.Action || string(w.Content) != string(msg.Content) {
+					t.Errorf("unexpected line %s", l)
+				}
+			}
+		})
+	}
+}
+
+func TestListSegments(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "annotations.jsonl")
+	names := []string{
+		"annotations.jsonl",
+		"annotations-20240102T030405.000006.jsonl",
+		"annotations-20240102T030405.000007.jsonl.gz",
+		"annotations-archive.jsonl",
+		"annotations-20240102T030405.jsonl",
+		"annotations-20240102T030405.000008.jsonl.gz.tmp",
+		"other-20240102T030405.000009.jsonl",
+	}
+	for _, name := range names {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0600); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	segments, err := listSegments(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	expected := []string{filepath.Join(dir, names[1]), filepath.Join(dir, names[2])}
+	if strings.Join(segments, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected segments %v, got %v", expected, segments)
+	}
+}
+
+func readLines(t *testing.T, path string) []string {
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		zr, err := gzip.NewReader(f)
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		r = zr
+	}
+
+	var lines []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
@@ -0,0 +1,468 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package file
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultPollInterval int = 500
+
+	// checkpointLines bounds the lines delivered between checkpoints, which are otherwise written when the subscriber
+	// catches up with the file, moves to another file or stops
+	checkpointLines = 100
+)
+
+// position identifies the first line that has not been acknowledged yet. Rotated segments are named after their
+// rotation time, so the file being read is the oldest segment sorting after After, or the active file if there is
+// none. This holds across rotations without having to track them.
+type position struct {
+	After  string `json:"after,omitempty"` // After is the base name of the last segment read completely
+	Offset int64  `json:"offset"`          // Offset in bytes, uncompressed
+}
+
+type fileSubscriber struct {
+	cfg    config.FileConfig
+	logger interfaces.Logger
+
+	// The following are owned by Subscribe while it runs
+	pos     position
+	saved   position // last position recorded at OffsetPath
+	unsaved int      // lines acknowledged since the last checkpoint
+	file    *os.File
+	zr      *gzip.Reader
+	reader  *bufio.Reader
+	pending []byte // incomplete line at the end of the file
+	segment string // base name of the file being read once it is known to be a rotated segment, which is complete
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	running   sync.WaitGroup
+}
+
+// NewFileSubscriber prepares a subscriber that follows the file written by the file stream provider.
+func NewFileSubscriber(cfg config.FileConfig, logger interfaces.Logger) (interfaces.StreamSubscriber, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("file stream path must be provided")
+	}
+	if cfg.PollInterval == 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	p := fileSubscriber{
+		cfg:    cfg,
+		logger: logger,
+		closed: make(chan struct{}),
+	}
+	return &p, nil
+}
+
+// Connect restores the acknowledged position. Without one, reading starts at the beginning of the active file,
+// which need not exist yet.
+func (p *fileSubscriber) Connect() error {
+	if p.cfg.OffsetPath != "" {
+		b, err := os.ReadFile(p.cfg.OffsetPath)
+		if err == nil {
+			err = json.Unmarshal(b, &p.pos)
+			if err != nil {
+				return fmt.Errorf("invalid position in %s: %w", p.cfg.OffsetPath, err)
+			}
+			p.saved = p.pos
+			return nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+	}
+
+	segments, err := p.listSegments()
+	if err != nil {
+		return err
+	}
+	if len(segments) > 0 {
+		p.pos.After = segments[len(segments)-1]
+	}
+	return nil
+}
+
+// Subscribe reads the file line by line and keeps following it as lines are appended. When the file is rotated,
+// the remainder of the rotated segment is read before moving on, so no line is skipped. Each line is acknowledged
+// once handler returns nil. A rejected line is redelivered after PollInterval until it is accepted, so lines are
+// always delivered in order. Lines that cannot be decoded are reported to onError and skipped.
+func (p *fileSubscriber) Subscribe(ctx context.Context, handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) (err error) {
+	if onError == nil {
+		onError = func(err error) {
+			p.logger.Error(err.Error())
+		}
+	}
+
+	p.running.Add(1)
+	defer p.running.Done()
+	defer p.closeFile()
+	defer func() {
+		if checkpointErr := p.checkpoint(); err == nil {
+			err = checkpointErr
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.closed:
+			return nil
+		default:
+		}
+
+		line, err := p.next()
+		if err != nil {
+			return err
+		}
+		if line == nil {
+			// Caught up with the file
+			err = p.checkpoint()
+			if err != nil {
+				return err
+			}
+			if !p.wait(ctx) {
+				return nil
+			}
+			continue
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			if !p.deliver(ctx, line, handler, onError) {
+				return nil
+			}
+		}
+		p.pos.Offset += int64(len(line))
+		p.unsaved++
+		if p.unsaved >= checkpointLines {
+			err = p.checkpoint()
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Close stops a running Subscribe and waits for it to return.
+func (p *fileSubscriber) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+	})
+	p.running.Wait()
+	return nil
+}
+
+// next returns the next complete line including its terminating newline, or nil if none is available yet.
+func (p *fileSubscriber) next() ([]byte, error) {
+	for {
+		if p.file == nil {
+			err := p.open()
+			if errors.Is(err, os.ErrNotExist) {
+				return nil, nil
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		b, err := p.reader.ReadBytes('\n')
+		if err == nil {
+			line := append(p.pending, b...)
+			p.pending = nil
+			return line, nil
+		}
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		// Hold on to a partially written line until the rest of it arrives
+		p.pending = append(p.pending, b...)
+
+		if p.segment == "" {
+			changed, err := p.checkRotated()
+			if err != nil || !changed {
+				return nil, err
+			}
+			// Read whatever was appended to the file before it was rotated, or the truncated file from the start
+			continue
+		}
+
+		// Rotated segments are complete, carry on with the next file
+		if len(p.pending) > 0 {
+			p.logger.Error(fmt.Sprintf("discarding incomplete line at the end of %s", p.segment))
+		}
+		p.pos = position{After: p.segment}
+		p.closeFile()
+		err = p.checkpoint()
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// checkRotated compares the active file being read, which has been read to the end, with the one at Path. It
+// reports whether reading should carry on because the file was rotated or truncated.
+func (p *fileSubscriber) checkRotated() (bool, error) {
+	current, err := p.file.Stat()
+	if err != nil {
+		return false, err
+	}
+	latest, err := os.Stat(p.cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		// Between the rotation and creation of the new file
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if !os.SameFile(current, latest) {
+		// The open file remains readable after being renamed, compressed or even removed
+		segments, err := p.newerSegments()
+		if err != nil {
+			return false, err
+		}
+		if len(segments) == 0 {
+			p.logger.Error(fmt.Sprintf("rotated segment of %s was removed before it could be read", p.cfg.Path))
+			p.closeFile()
+			p.pos.Offset = 0
+			return true, nil
+		}
+		p.segment = segments[0]
+		return true, nil
+	}
+	if latest.Size() < p.pos.Offset+int64(len(p.pending)) {
+		p.logger.Error(fmt.Sprintf("%s was truncated, following it from the start", p.cfg.Path))
+		p.closeFile()
+		p.pos.Offset = 0
+		return true, p.checkpoint()
+	}
+	return false, nil
+}
+
+// open opens the file at the current position: the oldest segment sorting after After, or else the active file.
+func (p *fileSubscriber) open() error {
+	for {
+		segments, err := p.newerSegments()
+		if err != nil {
+			return err
+		}
+		if len(segments) > 0 {
+			err = p.openSegment(segments[0])
+			if errors.Is(err, os.ErrNotExist) {
+				// Pruned in the meantime
+				p.logger.Error(fmt.Sprintf("segment %s was removed before it could be read", segments[0]))
+				p.pos = position{After: segments[0]}
+				continue
+			}
+			return err
+		}
+
+		f, err := os.Open(p.cfg.Path)
+		if err != nil {
+			return err
+		}
+		// If the file was rotated before it was opened, it is a segment by now
+		segments, err = p.newerSegments()
+		if err != nil || len(segments) > 0 {
+			_ = f.Close()
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		return p.openActive(f)
+	}
+}
+
+func (p *fileSubscriber) openActive(f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+	// A recorded position beyond the end of the file belongs to a file that has since been replaced
+	if p.pos.Offset > info.Size() {
+		p.logger.Error(fmt.Sprintf("%s is shorter than the recorded position, following it from the start", p.cfg.Path))
+		p.pos.Offset = 0
+	}
+	_, err = f.Seek(p.pos.Offset, io.SeekStart)
+	if err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	p.file = f
+	p.reader = bufio.NewReader(f)
+	return nil
+}
+
+// openSegment opens the rotated segment with the given base name at the current offset, decompressing it if it has
+// been archived.
+func (p *fileSubscriber) openSegment(segment string) error {
+	dir, _, _ := nameParts(p.cfg.Path)
+	name := filepath.Join(dir, segment)
+
+	var r io.Reader
+	f, err := os.Open(name)
+	if err == nil {
+		r = f
+	} else if errors.Is(err, os.ErrNotExist) {
+		f, err = os.Open(name + compressedExt)
+		if err != nil {
+			return err
+		}
+		p.zr, err = gzip.NewReader(f)
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+		r = p.zr
+	} else {
+		return err
+	}
+
+	p.file = f
+	p.reader = bufio.NewReader(r)
+	p.segment = segment
+	_, err = io.CopyN(io.Discard, p.reader, p.pos.Offset)
+	if err != nil && !errors.Is(err, io.EOF) {
+		p.closeFile()
+		return err
+	}
+	return nil
+}
+
+// newerSegments returns the base names of the segments sorting after After, oldest first.
+func (p *fileSubscriber) newerSegments() ([]string, error) {
+	segments, err := p.listSegments()
+	if err != nil {
+		return nil, err
+	}
+	for i, s := range segments {
+		if s > p.pos.After {
+			return segments[i:], nil
+		}
+	}
+	return nil, nil
+}
+
+// listSegments returns the base names of all rotated segments, without the extension of compressed ones, oldest
+// first.
+func (p *fileSubscriber) listSegments() ([]string, error) {
+	paths, err := listSegments(p.cfg.Path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var segments []string
+	for _, s := range paths {
+		name := strings.TrimSuffix(filepath.Base(s), compressedExt)
+		// A segment may be listed twice while it is being compressed
+		if len(segments) == 0 || segments[len(segments)-1] != name {
+			segments = append(segments, name)
+		}
+	}
+	return segments, nil
+}
+
+func (p *fileSubscriber) closeFile() {
+	if p.zr != nil {
+		_ = p.zr.Close()
+		p.zr = nil
+	}
+	if p.file != nil {
+		_ = p.file.Close()
+		p.file = nil
+		p.reader = nil
+		p.pending = nil
+		p.segment = ""
+	}
+}
+
+// deliver hands the messages of the line to handler in turn, each until it is accepted. It returns false if the
+// subscription was stopped first.
+func (p *fileSubscriber) deliver(ctx context.Context, line []byte, handler interfaces.DeliveryHandler,
+	onError interfaces.ErrorHandler) bool {
+	deliveries, err := message.NewDeliveries(line, p.cfg.Path)
+	if err != nil {
+		onError(&message.DeliveryError{Source: p.cfg.Path, Payload: line, Err: err})
+		return true
+	}
+
+	for _, d := range deliveries {
+		for {
+			err = handler(ctx, d)
+			if err == nil {
+				break
+			}
+			onError(&message.DeliveryError{Source: p.cfg.Path, Payload: line, Err: err})
+			if !p.wait(ctx) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// checkpoint records the acknowledged position if it has changed.
+func (p *fileSubscriber) checkpoint() error {
+	p.unsaved = 0
+	if p.cfg.OffsetPath == "" || p.pos == p.saved {
+		return nil
+	}
+	b, _ := json.Marshal(p.pos)
+	err := atomicfile.Write(p.cfg.OffsetPath, b, 0600)
+	if err != nil {
+		return err
+	}
+	p.saved = p.pos
+	return nil
+}
+
+// wait pauses for PollInterval. It returns false if the subscription was stopped in the meantime.
+func (p *fileSubscriber) wait(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-p.closed:
+		return false
+	case <-time.After(time.Millisecond * time.Duration(p.cfg.PollInterval)):
+		return true
+	}
+}
@@ -0,0 +1,161 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package file
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+func startSubscriber(t *testing.T, cfg config.FileConfig, c *testutil.Collector) (interfaces.StreamSubscriber, chan error) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	s, err := NewFileSubscriber(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return s, testutil.StartSubscriber(t, s, c)
+}
+
+func TestFileSubscriber(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	dir := t.TempDir()
+	cfg := config.FileConfig{
+		Path:         filepath.Join(dir, "annotations.jsonl"),
+		MaxSize:      1024,
+		Compress:     true,
+		OffsetPath:   filepath.Join(dir, "annotations.offset"),
+		PollInterval: 5,
+	}
+
+	// Subscribe before the file exists, the first delivery is rejected and must be redelivered
+	c := testutil.NewCollector(1)
+	s, done := startSubscriber(t, cfg, c)
+
+	p, err := NewFilePublisher(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	err = p.Connect()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	for i := 0; i < 3; i++ {
+		_ = p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+	}
+	f, _ := os.OpenFile(cfg.Path, os.O_APPEND|os.O_WRONLY, 0600)
+	_, _ = f.WriteString("not json\n")
+	_ = f.Close()
+	c.Await(t, 3)
+
+	// Enough messages to rotate the file several times while it is being followed
+	for i := 3; i < 20; i++ {
+		_ = p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+	}
+	c.Await(t, 17)
+	_ = s.Close()
+	if err = <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i, d := range c.Received() {
+		if d.Action != message.ActionCreate || len(d.Annotations.Items) != 1 ||
+			d.Annotations.Items[0].Key != fmt.Sprintf("key%d", i) || d.Source != cfg.Path {
+			t.Errorf("unexpected delivery %d %v", i, d)
+		}
+	}
+	if len(c.Errors()) != 2 {
+		t.Errorf("expected a rejection and a decoding error, got %v", c.Errors())
+	}
+
+	// A new subscriber resumes after the last acknowledged line
+	_ = p.Publish(testutil.AnnotationMessage("resumed"))
+	_ = p.Close()
+	c = testutil.NewCollector(0)
+	s, done = startSubscriber(t, cfg, c)
+	c.Await(t, 1)
+	_ = s.Close()
+	<-done
+	if received := c.Received(); len(received) != 1 || received[0].Annotations.Items[0].Key != "resumed" {
+		t.Errorf("unexpected deliveries after resume %v", received)
+	}
+}
+
+func TestFileSubscriberResumeAfterRotation(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	dir := t.TempDir()
+	cfg := config.FileConfig{
+		Path:         filepath.Join(dir, "annotations.jsonl"),
+		MaxSize:      1024,
+		Compress:     true,
+		OffsetPath:   filepath.Join(dir, "annotations.offset"),
+		PollInterval: 5,
+	}
+	// Files sharing the name of the active file are not segments
+	_ = os.WriteFile(filepath.Join(dir, "annotations-archive.jsonl"), []byte("not json\n"), 0600)
+
+	p, err := NewFilePublisher(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	err = p.Connect()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer p.Close()
+	_ = p.Publish(testutil.AnnotationMessage("key0"))
+
+	c := testutil.NewCollector(0)
+	s, done := startSubscriber(t, cfg, c)
+	c.Await(t, 1)
+	_ = s.Close()
+	if err = <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The file being followed is rotated several times while nobody is reading it
+	for i := 1; i < 12; i++ {
+		_ = p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+	}
+	segments, _ := listSegments(cfg.Path)
+	if len(segments) < 2 {
+		t.Fatalf("expected the file to be rotated, got %v", segments)
+	}
+
+	c = testutil.NewCollector(0)
+	s, done = startSubscriber(t, cfg, c)
+	c.Await(t, 11)
+	_ = s.Close()
+	if err = <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	received := c.Received()
+	for i, d := range received {
+		if d.Annotations.Items[0].Key != fmt.Sprintf("key%d", i+1) {
+			t.Errorf("unexpected delivery %d %v", i, d)
+		}
+	}
+	if len(received) != 11 || len(c.Errors()) != 0 {
+		t.Errorf("unexpected %d deliveries and errors %v", len(received), c.Errors())
+	}
+}
@@ -0,0 +1,209 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/grpcconn"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/ingest"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	defaultConnectTimeout int = 5000
+	defaultAckTimeout     int = 5000
+)
+
+type grpcPublisher struct {
+	cfg         config.GrpcConfig
+	logger      interfaces.Logger
+	dialOptions []grpc.DialOption
+	conn        *grpc.ClientConn
+	client      ingest.IngestClient
+
+	sendMutex sync.Mutex // serializes sends on the stream, which must not be concurrent
+	mutex     sync.Mutex // guards the fields below, never held while sending
+	stream    ingest.Ingest_PublishClient
+	cancel    context.CancelFunc
+	sequence  uint64
+	waiting   map[uint64]chan error // acks awaited by Publish, keyed by sequence
+}
+
+// NewGrpcPublisher validates the configuration and prepares a publisher. No connection to the collector is made
+// until Connect is called.
+func NewGrpcPublisher(cfg config.GrpcConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+	if cfg.AckTimeout == 0 {
+		cfg.AckTimeout = defaultAckTimeout
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	p := grpcPublisher{
+		cfg:         cfg,
+		logger:      logger,
+		dialOptions: []grpc.DialOption{grpc.WithTransportCredentials(creds)},
+		waiting:     make(map[uint64]chan error),
+	}
+	return &p, nil
+}
+
+// Connect establishes the collector connection. The publishing stream is opened by the first publish and reopened
+// by the next one should it fail, while the underlying connection re-establishes itself.
+func (p *grpcPublisher) Connect() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.ConnectTimeout))
+	defer cancel()
+
+	conn, err := grpcconn.Connect(ctx, p.cfg.Provider.Address(), p.dialOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to collector %s: %w", p.cfg.Provider.Address(), err)
+	}
+	p.conn = conn
+	p.client = ingest.NewIngestClient(conn)
+	return nil
+}
+
+// Publish sends the message on the stream and waits for the collector to acknowledge it. Concurrent publishes share
+// the stream, each waiting for its own ack. A message rejected by the collector is returned as an error.
+func (p *grpcPublisher) Publish(msg message.PublishWrapper) error {
+	if p.client == nil {
+		return errors.New("grpc publisher is not connected")
+	}
+
+	ack := make(chan error, 1)
+	p.sendMutex.Lock()
+	p.mutex.Lock()
+	stream, err := p.open()
+	if err != nil {
+		p.mutex.Unlock()
+		p.sendMutex.Unlock()
+		return err
+	}
+	p.sequence++
+	sequence := p.sequence
+	p.waiting[sequence] = ack
+	p.mutex.Unlock()
+
+	p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, sequence %d", sequence))
+	err = stream.Send(&ingest.PublishRequest{Sequence: sequence, Wrapper: ingest.FromWrapper(msg)})
+	p.sendMutex.Unlock()
+	// A failed send means the stream ended, the cause is reported to the waiting publishes once it is received
+	if err != nil && !errors.Is(err, io.EOF) {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("failed to send sequence %d: %s", sequence, err.Error()))
+	}
+
+	select {
+	case err = <-ack:
+		return err
+	case <-time.After(time.Millisecond * time.Duration(p.cfg.AckTimeout)):
+		p.mutex.Lock()
+		delete(p.waiting, sequence)
+		p.mutex.Unlock()
+		return fmt.Errorf("timed out waiting for collector to acknowledge message %d", sequence)
+	}
+}
+
+func (p *grpcPublisher) Close() error {
+	p.mutex.Lock()
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.mutex.Unlock()
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+// open returns the current stream, opening a new one if there is none. Must be called with mutex held.
+func (p *grpcPublisher) open() (ingest.Ingest_PublishClient, error) {
+	if p.stream != nil {
+		return p.stream, nil
+	}
+
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(context.Background(), metadata.New(p.cfg.Metadata)))
+	stream, err := p.client.Publish(ctx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("failed to open stream to collector %s: %w", p.cfg.Provider.Address(), err)
+	}
+	p.stream = stream
+	p.cancel = cancel
+	go p.receive(stream, cancel)
+	return stream, nil
+}
+
+// receive passes the acks read from the stream to the waiting publishes until the stream ends, at which point all
+// publishes still waiting fail.
+func (p *grpcPublisher) receive(stream ingest.Ingest_PublishClient, cancel context.CancelFunc) {
+	defer cancel()
+	for {
+		ack, err := stream.Recv()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = errors.New("stream closed by collector")
+			}
+			p.mutex.Lock()
+			waiting := p.waiting
+			p.waiting = make(map[uint64]chan error)
+			p.stream = nil
+			p.mutex.Unlock()
+
+			for sequence, ch := range waiting {
+				ch <- fmt.Errorf("failed to publish message %d: %w", sequence, err)
+			}
+			if len(waiting) > 0 {
+				p.logger.Error(fmt.Sprintf("collector stream failed, it is reopened on the next publish: %s", err.Error()))
+			}
+			return
+		}
+
+		p.mutex.Lock()
+		ch, ok := p.waiting[ack.GetSequence()]
+		delete(p.waiting, ack.GetSequence())
+		p.mutex.Unlock()
+		if !ok {
+			continue // the publish gave up waiting
+		}
+		if !ack.GetAccepted() {
+			ch <- fmt.Errorf("collector rejected message %d: %s", ack.GetSequence(), ack.GetError())
+			continue
+		}
+		ch <- nil
+	}
+}
@@ -0,0 +1,195 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/collector"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestPublisher connects a publisher to a collector served in-process. The collector fails the first failStreams
+// streams opened to it.
+func newTestPublisher(t *testing.T, cfg config.GrpcConfig, handler func(ctx context.Context, d message.Delivery) error,
+	failStreams int) *grpcPublisher {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	var mutex sync.Mutex
+	interceptor := func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, h grpc.StreamHandler) error {
+		mutex.Lock()
+		fail := failStreams > 0
+		failStreams--
+		mutex.Unlock()
+		if fail {
+			return status.Error(codes.Unavailable, "collector restarting")
+		}
+		return h(srv, ss)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.StreamInterceptor(interceptor))
+	collector.NewCollector(handler, func(error) {}, logger).Register(server)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	p, err := NewGrpcPublisher(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	publisher := p.(*grpcPublisher)
+	publisher.dialOptions = append(publisher.dialOptions, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	if err = publisher.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	t.Cleanup(func() { _ = publisher.Close() })
+	return publisher
+}
+
+func TestGrpcPublisher(t *testing.T) {
+	// Holds the first delivery of the ack timeout case until its publish has given up
+	release := make(chan struct{})
+
+	tests := []struct {
+		name        string
+		handler     func(ctx context.Context, d message.Delivery) error
+		failStreams int
+		expectError string // expected in the error of the first publish, which is retried once if set
+	}{
+		{"accepted", func(ctx context.Context, d message.Delivery) error { return nil }, 0, ""},
+		{"rejected", func(ctx context.Context, d message.Delivery) error {
+			if d.Sequence == 1 {
+				return errors.New("storage unavailable")
+			}
+			return nil
+		}, 0, "storage unavailable"},
+		{"ack timeout", func(ctx context.Context, d message.Delivery) error {
+			if d.Sequence == 1 {
+				<-release
+			}
+			return nil
+		}, 0, "timed out"},
+		{"stream reopened", func(ctx context.Context, d message.Delivery) error { return nil }, 1, "collector restarting"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mutex sync.Mutex
+			var received []message.Delivery
+			handler := func(ctx context.Context, d message.Delivery) error {
+				err := tt.handler(ctx, d)
+				if err == nil {
+					md, _ := metadata.FromIncomingContext(ctx)
+					if len(md.Get("authorization")) != 1 || md.Get("authorization")[0] != "Bearer token" {
+						return fmt.Errorf("unexpected metadata %v", md)
+					}
+					mutex.Lock()
+					received = append(received, d)
+					mutex.Unlock()
+				}
+				return err
+			}
+			cfg := config.GrpcConfig{Metadata: map[string]string{"authorization": "Bearer token"}, AckTimeout: 100}
+			p := newTestPublisher(t, cfg, handler, tt.failStreams)
+
+			err := p.Publish(testutil.AnnotationMessage("key0"))
+			if tt.expectError == "" && err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if tt.expectError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectError) {
+					t.Fatalf("expected error containing %q, received %v", tt.expectError, err)
+				}
+				if tt.name == "ack timeout" {
+					close(release)
+				}
+				err = p.Publish(testutil.AnnotationMessage("key0"))
+				if err != nil {
+					t.Fatalf("unexpected error on retry %v", err)
+				}
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			// A message that timed out waiting for its ack may still have been accepted
+			if len(received) == 0 {
+				t.Fatalf("expected a delivery")
+			}
+			d := received[len(received)-1]
+			if d.Action != message.ActionCreate || len(d.Annotations.Items) != 1 || d.Annotations.Items[0].Key != "key0" ||
+				d.Timestamp.IsZero() {
+				t.Errorf("unexpected delivery %v", d)
+			}
+		})
+	}
+}
+
+func TestGrpcPublisherConcurrent(t *testing.T) {
+	var mutex sync.Mutex
+	sequences := make(map[uint64]bool)
+	handler := func(ctx context.Context, d message.Delivery) error {
+		mutex.Lock()
+		defer mutex.Unlock()
+		sequences[d.Sequence] = true
+		return nil
+	}
+	p := newTestPublisher(t, config.GrpcConfig{}, handler, 0)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 50)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+	if len(sequences) != 50 {
+		t.Errorf("expected 50 distinct sequences, received %d", len(sequences))
+	}
+}
+
+func TestGrpcPublisherNotConnected(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	p, err := NewGrpcPublisher(config.GrpcConfig{}, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Publish(testutil.AnnotationMessage("key0")); err == nil {
+		t.Errorf("expected an error publishing before Connect")
+	}
+}
@@ -0,0 +1,70 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package grpcconn
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestConnect(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	tests := []struct {
+		name        string
+		dial        func(ctx context.Context) (net.Conn, error)
+		expectError bool
+	}{
+		{"ready", listener.DialContext, false},
+		{"unreachable", func(ctx context.Context) (net.Conn, error) {
+			return nil, errors.New("connection refused")
+		}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+
+			dial := tt.dial
+			conn, err := Connect(ctx, "localhost:50051", grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+					return dial(ctx)
+				}))
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			defer conn.Close()
+			if conn.GetState() != connectivity.Ready {
+				t.Errorf("unexpected state %s", conn.GetState())
+			}
+		})
+	}
+}
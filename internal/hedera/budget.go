@@ -0,0 +1,161 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+)
+
+// ErrBudgetExhausted is returned by the Hedera publisher under the contracts.BudgetPause policy while a spend limit
+// has been reached.
+var ErrBudgetExhausted = errors.New("hedera budget exhausted")
+
+// spend is the HBAR spent in tinybars during the current hour and day, as recorded at the budget's StatePath
+type spend struct {
+	Hour      time.Time `json:"hour"`
+	HourSpent int64     `json:"hourSpent"`
+	Day       time.Time `json:"day"`
+	DaySpent  int64     `json:"daySpent"`
+}
+
+// budget tracks transaction fees against the configured hourly and daily limits
+type budget struct {
+	cfg    config.HederaBudget
+	logger interfaces.Logger
+	now    func() time.Time
+
+	mutex     sync.Mutex // guards spend and exhausted
+	spend     spend
+	exhausted bool
+}
+
+func newBudget(cfg config.HederaBudget, logger interfaces.Logger) (*budget, error) {
+	if cfg.OnExhausted == "" {
+		cfg.OnExhausted = contracts.BudgetPause
+	}
+	b := budget{
+		cfg:    cfg,
+		logger: logger,
+		now:    time.Now,
+	}
+	if cfg.StatePath == "" {
+		return &b, nil
+	}
+
+	data, err := os.ReadFile(cfg.StatePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return &b, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(data, &b.spend)
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget state in %s: %w", cfg.StatePath, err)
+	}
+	return &b, nil
+}
+
+// check reports whether a limit has been reached and, if so, describes it. Changes between the exhausted and
+// available states are logged.
+func (b *budget) check() (string, bool) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.roll()
+	reason, until := "", time.Time{}
+	if b.cfg.HourlyLimit > 0 && b.spend.HourSpent >= limit(b.cfg.HourlyLimit) {
+		reason = fmt.Sprintf("spent %s of the hourly limit of %s",
+			hedera.HbarFromTinybar(b.spend.HourSpent), hedera.HbarFromTinybar(limit(b.cfg.HourlyLimit)))
+		until = b.spend.Hour.Add(time.Hour)
+	}
+	if b.cfg.DailyLimit > 0 && b.spend.DaySpent >= limit(b.cfg.DailyLimit) {
+		reason = fmt.Sprintf("spent %s of the daily limit of %s",
+			hedera.HbarFromTinybar(b.spend.DaySpent), hedera.HbarFromTinybar(limit(b.cfg.DailyLimit)))
+		until = b.spend.Day.AddDate(0, 0, 1)
+	}
+
+	exhausted := reason != ""
+	if exhausted && !b.exhausted {
+		action := "publishing is paused"
+		if b.cfg.OnExhausted == contracts.BudgetFallback {
+			action = "publishing to the fallback stream instead"
+		}
+		b.logger.Error(fmt.Sprintf("hedera budget exhausted, %s; %s until %s", reason, action, until.Format(time.RFC3339)))
+	} else if !exhausted && b.exhausted {
+		b.logger.Write(slog.LevelInfo, "hedera budget available, publishing to hedera resumed")
+	}
+	b.exhausted = exhausted
+	return reason, exhausted
+}
+
+// charge adds the fee of a transaction to the spend. Failing to record the spend is logged rather than returned since
+// the transaction has already been paid for.
+func (b *budget) charge(fee hedera.Hbar) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.roll()
+	b.spend.HourSpent += fee.AsTinybar()
+	b.spend.DaySpent += fee.AsTinybar()
+	b.logger.Write(slog.LevelDebug, fmt.Sprintf("hedera transaction fee %s, spent %s this hour and %s today",
+		fee, hedera.HbarFromTinybar(b.spend.HourSpent), hedera.HbarFromTinybar(b.spend.DaySpent)))
+
+	err := b.save()
+	if err != nil {
+		b.logger.Error(fmt.Sprintf("failed to record hedera budget to %s: %s", b.cfg.StatePath, err.Error()))
+	}
+}
+
+// roll starts a new hour or day once the current one has passed. Must be called with the mutex held.
+func (b *budget) roll() {
+	now := b.now().UTC()
+	hour := now.Truncate(time.Hour)
+	if !hour.Equal(b.spend.Hour) {
+		b.spend.Hour = hour
+		b.spend.HourSpent = 0
+	}
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	if !day.Equal(b.spend.Day) {
+		b.spend.Day = day
+		b.spend.DaySpent = 0
+	}
+}
+
+// save records the spend at StatePath. Must be called with the mutex held.
+func (b *budget) save() error {
+	if b.cfg.StatePath == "" {
+		return nil
+	}
+	data, _ := json.Marshal(b.spend)
+	return atomicfile.Write(b.cfg.StatePath, data, 0600)
+}
+
+// limit converts a limit in HBAR to tinybars
+func limit(hbar float64) int64 {
+	return hedera.HbarFrom(hbar, hedera.HbarUnits.Hbar).AsTinybar()
+}
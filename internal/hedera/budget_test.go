@@ -0,0 +1,99 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+func TestHederaPublisherBudget(t *testing.T) {
+	tests := []struct {
+		name            string
+		budget          config.HederaBudget
+		advance         time.Duration // between the two rounds of four publishes
+		expectSubmitted int
+		expectFallback  int
+		expectExhausted int
+		expectRestarted bool // whether the budget is still exhausted after a restart
+	}{
+		{"under limit", config.HederaBudget{HourlyLimit: 1}, 0, 8, 0, 0, false},
+		{"hourly pause", config.HederaBudget{HourlyLimit: 0.25}, 0, 3, 0, 5, true},
+		{"hourly resume", config.HederaBudget{HourlyLimit: 0.25}, time.Hour, 6, 0, 2, true},
+		{"daily pause", config.HederaBudget{HourlyLimit: 1, DailyLimit: 0.25}, time.Hour, 3, 0, 5, true},
+		{"daily resume", config.HederaBudget{DailyLimit: 0.25}, 24 * time.Hour, 6, 0, 2, true},
+		{"fallback", config.HederaBudget{DailyLimit: 0.25, OnExhausted: contracts.BudgetFallback}, 0, 3, 5, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.budget.StatePath = filepath.Join(t.TempDir(), "budget.json")
+			cfg := config.HederaConfig{Topics: []string{"0.0.2001"}, Budget: tt.budget}
+
+			var fallback interfaces.StreamProvider
+			stream := &recordingStream{}
+			if tt.budget.OnExhausted == contracts.BudgetFallback {
+				fallback = stream
+			}
+			now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+			clock := func() time.Time {
+				return now
+			}
+
+			p := newTestPublisher(t, cfg, nil, fallback)
+			p.budget.now = clock
+			submitted := 0
+			p.submit = func(_ hedera.TopicID, b []byte) ([]hedera.TransactionResponse, error) {
+				submitted++
+				return submitChunks(b), nil
+			}
+			p.confirm = func(topic string, _ hedera.TransactionResponse) (message.ConsensusProof, hedera.Hbar, error) {
+				return message.ConsensusProof{TopicId: topic}, hedera.HbarFrom(0.1, hedera.HbarUnits.Hbar), nil
+			}
+
+			wrapper := testutil.AnnotationMessage("key0")
+			exhausted := 0
+			for i := 0; i < 8; i++ {
+				if i == 4 {
+					now = now.Add(tt.advance)
+				}
+				err := p.Publish(wrapper)
+				if errors.Is(err, ErrBudgetExhausted) {
+					exhausted++
+				} else if err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+
+			if submitted != tt.expectSubmitted || len(stream.published) != tt.expectFallback || exhausted != tt.expectExhausted {
+				t.Errorf("unexpected %d submitted, %d to fallback, %d exhausted", submitted, len(stream.published), exhausted)
+			}
+
+			restarted := newTestPublisher(t, cfg, nil, fallback)
+			restarted.budget.now = clock
+			if _, exhausted := restarted.budget.check(); exhausted != tt.expectRestarted {
+				t.Errorf("unexpected exhausted state %v after restart", exhausted)
+			}
+		})
+	}
+}
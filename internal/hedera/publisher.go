@@ -15,54 +15,114 @@
 package hedera
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hashgraph/hedera-sdk-go/v2"
-	"github.com/project-alvarium/alvarium-sdk-go/internal/mqtt"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
 )
 
+// gzipMagic starts every gzip stream
+var gzipMagic = []byte{0x1f, 0x8b}
+
 type HederaPublisher struct {
 	cfg             config.HederaConfig
 	logger          interfaces.Logger
 	hederaClient    *hedera.Client
 	broadcastStream interfaces.StreamProvider
+	fallbackStream  interfaces.StreamProvider
+	submitKey       *hedera.PrivateKey
+	budget          *budget // nil unless a spend limit is configured
+
+	// The following are replaced in tests
+	submit      func(topicId hedera.TopicID, b []byte) ([]hedera.TransactionResponse, error)
+	confirm     func(topic string, resp hedera.TransactionResponse) (message.ConsensusProof, hedera.Hbar, error)
+	topicExists func(topicId hedera.TopicID) (bool, error)
+	createTopic func() (hedera.TopicID, error)
 }
 
-func NewHederaPublisher(cfg config.HederaConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+// NewHederaPublisher prepares a publisher to the configured topics. The broadcast stream announces the topics in
+// use and is required when ShouldBroadcastTopic is set. The fallback stream receives messages while the budget is
+// exhausted and is required under the contracts.BudgetFallback policy.
+func NewHederaPublisher(cfg config.HederaConfig, broadcast interfaces.StreamProvider,
+	fallback interfaces.StreamProvider, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.ShouldBroadcastTopic && broadcast == nil {
+		return nil, errors.New("broadcast stream must be provided when shouldBroadcastTopic is set")
+	}
+	if cfg.Budget.OnExhausted == contracts.BudgetFallback && fallback == nil {
+		return nil, errors.New("fallback stream must be provided for the fallback budget policy")
+	}
 	client, err := initHederaClient(cfg)
 	if err != nil {
 		return nil, err
 	}
 
 	p := HederaPublisher{
-		cfg:          cfg,
-		logger:       logger,
-		hederaClient: client,
+		cfg:             cfg,
+		logger:          logger,
+		hederaClient:    client,
+		broadcastStream: broadcast,
+		fallbackStream:  fallback,
 	}
+	if cfg.Budget.Enabled() {
+		p.budget, err = newBudget(cfg.Budget, logger)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.SubmitKeyPath != "" {
+		key, err := readPrivateKey(cfg.SubmitKeyPath)
+		if err != nil {
+			return nil, err
+		}
+		p.submitKey = &key
+	}
+	p.submit = p.submitMessage
+	p.confirm = p.consensusProof
+	p.topicExists = p.queryTopic
+	p.createTopic = p.newTopic
 	return &p, nil
 }
 
 // Connect initiates the connection to Hedera via the Hedera Client.
 // No need for manual initiation. Instead, topics used to
 // publish annotations will be broadcasted according to
-// configuration
+// configuration. Missing topics are created first if
+// configured.
 func (p *HederaPublisher) Connect() error {
-	if p.cfg.ShouldBroadcastTopic {
+	if p.cfg.CreateTopics {
+		err := p.provision()
+		if err != nil {
+			return err
+		}
+	}
+	if len(p.cfg.Topics) == 0 {
+		return errors.New("at least one Hedera topic must be provided or created")
+	}
+	if p.fallbackStream != nil {
+		err := p.fallbackStream.Connect()
+		if err != nil {
+			return err
+		}
+	}
 
-		stream, err := initBroadcastStream(p.cfg, p.logger)
+	if p.cfg.ShouldBroadcastTopic {
+		err := p.broadcastStream.Connect()
 		if err != nil {
 			return err
 		}
 
-		p.broadcastStream = stream
 		for _, topic := range p.cfg.Topics {
 			msg := message.PublishWrapper{
 				Action:      message.ActionBroadcast,
@@ -79,10 +139,48 @@ func (p *HederaPublisher) Connect() error {
 	return nil
 }
 
+// Publish submits the message to every topic, gzipped if Compress is set. The SDK splits messages larger than a
+// single consensus message into chunks, each a separate transaction. If WaitForReceipt is set, it waits for consensus
+// on each transaction and logs its proof. While the budget is exhausted, the message is published to the fallback stream or
+// ErrBudgetExhausted is returned, depending on the policy.
 func (p *HederaPublisher) Publish(msg message.PublishWrapper) error {
+	_, err := p.publish(msg, p.cfg.WaitForReceipt)
+	return err
+}
+
+// PublishWithProof publishes like Publish but always waits for consensus. It returns a proof for each message
+// submitted, ordered by topic and then chunk. No proofs are returned for a message published to the fallback stream.
+func (p *HederaPublisher) PublishWithProof(msg message.PublishWrapper) ([]message.ConsensusProof, error) {
+	return p.publish(msg, true)
+}
+
+func (p *HederaPublisher) publish(msg message.PublishWrapper, wait bool) ([]message.ConsensusProof, error) {
+	if p.budget != nil {
+		if reason, exhausted := p.budget.check(); exhausted {
+			if p.fallbackStream != nil {
+				return nil, p.fallbackStream.Publish(msg)
+			}
+			return nil, fmt.Errorf("%w, %s", ErrBudgetExhausted, reason)
+		}
+	}
+
 	b, _ := json.Marshal(msg)
+	payload := b
+	if p.cfg.Compress {
+		var err error
+		payload, err = compress(b)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var annotationIds []string
+	for _, a := range msg.Annotations() {
+		annotationIds = append(annotationIds, a.Id.String())
+	}
 
 	// publish to all topic IDs
+	var proofs []message.ConsensusProof
 	for _, topic := range p.cfg.Topics {
 		p.logger.Write(
 			slog.LevelDebug,
@@ -90,20 +188,41 @@ func (p *HederaPublisher) Publish(msg message.PublishWrapper) error {
 		)
 		topicId, err := hedera.TopicIDFromString(topic)
 		if err != nil {
-			return err
+			return proofs, err
 		}
 
 		// submit message to consensus service
-		_, err = hedera.NewTopicMessageSubmitTransaction().
-			SetMessage(b).
-			SetTopicID(topicId).
-			Execute(p.hederaClient)
+		responses, err := p.submit(topicId, payload)
 		if err != nil {
-			return err
+			return proofs, err
+		}
+		for _, resp := range responses {
+			// The fee charged is only known once the transaction has reached consensus
+			if !wait && p.budget == nil {
+				continue
+			}
+
+			proof, fee, err := p.confirm(topic, resp)
+			if err != nil {
+				return proofs, fmt.Errorf("no receipt for transaction %s on topic %s: %w", resp.TransactionID, topic, err)
+			}
+			if p.budget != nil {
+				p.budget.charge(fee)
+			}
+			if !wait {
+				continue
+			}
+			proof.AnnotationIds = annotationIds
+			p.logger.Write(slog.LevelInfo, fmt.Sprintf(
+				"consensus reached, annotations %s topic %s sequence %d running hash %x timestamp %s transaction %s",
+				strings.Join(annotationIds, ","), proof.TopicId, proof.Sequence, proof.RunningHash,
+				proof.Timestamp.Format(time.RFC3339Nano), proof.TransactionId,
+			))
+			proofs = append(proofs, proof)
 		}
 	}
 
-	return nil
+	return proofs, nil
 }
 
 // Close notifies parties aware of Hedera topics that the stream is closing
@@ -121,25 +240,107 @@ func (p *HederaPublisher) Close() error {
 			}
 
 		}
+		err := p.broadcastStream.Close()
+		if err != nil {
+			return err
+		}
+	}
+	if p.fallbackStream != nil {
+		err := p.fallbackStream.Close()
+		if err != nil {
+			return err
+		}
 	}
 	return p.hederaClient.Close()
 }
 
+// submitMessage submits b to the topic. Messages larger than a single consensus message are split by the SDK into
+// chunks of HederaMaxChunkSize bytes, returning a response for each chunk in order. Submitting fails without any
+// transaction if more than MaxChunks would be needed.
+func (p *HederaPublisher) submitMessage(topicId hedera.TopicID, b []byte) ([]hedera.TransactionResponse, error) {
+	tx := hedera.NewTopicMessageSubmitTransaction().
+		SetMessage(b).
+		SetTopicID(topicId)
+	if p.cfg.MaxChunks > 0 {
+		tx.SetMaxChunks(uint64(p.cfg.MaxChunks))
+	}
+	if p.submitKey != nil {
+		_, err := tx.FreezeWith(p.hederaClient)
+		if err != nil {
+			return nil, err
+		}
+		tx.Sign(*p.submitKey)
+	}
+	return tx.ExecuteAll(p.hederaClient)
+}
+
+// compress gzips an encoded PublishWrapper. Subscribers tell it apart from an uncompressed one by the gzip magic
+// number, since the JSON encoding starts with '{'.
+func compress(b []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(b)
+	if err != nil {
+		return nil, err
+	}
+	err = w.Close()
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompress reverses compress, returning b unchanged if it is not gzipped.
+func decompress(b []byte) ([]byte, error) {
+	if !bytes.HasPrefix(b, gzipMagic) {
+		return b, nil
+	}
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return io.ReadAll(r)
+}
+
+// consensusProof waits for the receipt of the transaction, or its record if configured or a budget is being tracked.
+// The record also carries the consensus timestamp and the fee charged, which is otherwise zero.
+func (p *HederaPublisher) consensusProof(topic string, resp hedera.TransactionResponse) (message.ConsensusProof, hedera.Hbar, error) {
+	var receipt hedera.TransactionReceipt
+	var timestamp time.Time
+	var fee hedera.Hbar
+	if p.cfg.FetchRecord || p.budget != nil {
+		record, err := resp.GetRecord(p.hederaClient)
+		if err != nil {
+			return message.ConsensusProof{}, fee, err
+		}
+		receipt = record.Receipt
+		timestamp = record.ConsensusTimestamp
+		fee = record.TransactionFee
+	} else {
+		var err error
+		receipt, err = resp.GetReceipt(p.hederaClient)
+		if err != nil {
+			return message.ConsensusProof{}, fee, err
+		}
+	}
+
+	return message.ConsensusProof{
+		TopicId:            topic,
+		Sequence:           receipt.TopicSequenceNumber,
+		RunningHash:        receipt.TopicRunningHash,
+		RunningHashVersion: receipt.TopicRunningHashVersion,
+		Timestamp:          timestamp,
+		TransactionId:      resp.TransactionID.String(),
+	}, fee, nil
+}
+
 // Initialize a Hedera client with configuration driven values
 // and default values
 func initHederaClient(cfg config.HederaConfig) (*hedera.Client, error) {
-	var client *hedera.Client
-	switch netType := cfg.NetType; netType {
-	case contracts.Mainnet:
-		client = hedera.ClientForMainnet()
-	case contracts.Testnet:
-		client = hedera.ClientForTestnet()
-	case contracts.Previewnet:
-		client = hedera.ClientForPreviewnet()
-	case contracts.Local:
-		client = clientForLocal(cfg)
-	default:
-		return nil, errors.New("nettype not valid")
+	client, err := clientForNetwork(cfg)
+	if err != nil {
+		return nil, err
 	}
 
 	accountId, err := hedera.AccountIDFromString(cfg.AccountId)
@@ -147,7 +348,7 @@ func initHederaClient(cfg config.HederaConfig) (*hedera.Client, error) {
 		return nil, err
 	}
 
-	privateKey, err := readPrivateKey(cfg)
+	privateKey, err := readPrivateKey(cfg.PrivateKeyPath)
 	if err != nil {
 		return nil, err
 	}
@@ -162,6 +363,23 @@ func initHederaClient(cfg config.HederaConfig) (*hedera.Client, error) {
 	return client, nil
 }
 
+// clientForNetwork returns a client for the configured network without an operator, which is sufficient for
+// querying the mirror node
+func clientForNetwork(cfg config.HederaConfig) (*hedera.Client, error) {
+	switch netType := cfg.NetType; netType {
+	case contracts.Mainnet:
+		return hedera.ClientForMainnet(), nil
+	case contracts.Testnet:
+		return hedera.ClientForTestnet(), nil
+	case contracts.Previewnet:
+		return hedera.ClientForPreviewnet(), nil
+	case contracts.Local:
+		return clientForLocal(cfg), nil
+	default:
+		return nil, errors.New("nettype not valid")
+	}
+}
+
 func clientForLocal(cfg config.HederaConfig) *hedera.Client {
 	node := make(map[string]hedera.AccountID, 1)
 	consensus := cfg.Consensus.Address()
@@ -175,8 +393,8 @@ func clientForLocal(cfg config.HederaConfig) *hedera.Client {
 	return client
 }
 
-func readPrivateKey(cfg config.HederaConfig) (hedera.PrivateKey, error) {
-	b, err := os.ReadFile(cfg.PrivateKeyPath)
+func readPrivateKey(path string) (hedera.PrivateKey, error) {
+	b, err := os.ReadFile(path)
 	if err != nil {
 		return hedera.PrivateKey{}, err
 	}
@@ -216,16 +434,3 @@ func setDefaultValues(client *hedera.Client, cfg config.HederaConfig) error {
 
 	return nil
 }
-
-func initBroadcastStream(
-	cfg config.HederaConfig,
-	logger interfaces.Logger,
-) (interfaces.StreamProvider, error) {
-	stream := mqtt.NewMqttPublisher(cfg.BroadcastStream, logger)
-
-	err := stream.Connect()
-	if err != nil {
-		return nil, err
-	}
-	return stream, nil
-}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func newTestPublisher(t *testing.T, cfg config.HederaConfig, broadcast interfaces.StreamProvider,
+	fallback interfaces.StreamProvider) *HederaPublisher {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg.NetType = contracts.Local
+	cfg.AccountId = "0.0.1001"
+	cfg.PrivateKeyPath = "../../test/keys/hedera/hedera.private"
+	p, err := NewHederaPublisher(cfg, broadcast, fallback, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return p.(*HederaPublisher)
+}
+
+// submitChunks returns a response for each chunk the SDK would split b into.
+func submitChunks(b []byte) []hedera.TransactionResponse {
+	return make([]hedera.TransactionResponse, (len(b)+config.HederaMaxChunkSize-1)/config.HederaMaxChunkSize)
+}
+
+func TestHederaPublisherProofs(t *testing.T) {
+	tests := []struct {
+		name         string
+		annotations  int
+		cfg          config.HederaConfig
+		withProof    bool
+		confirmErr   error
+		expectProofs int
+		expectError  bool
+	}{
+		{"no receipts", 1, config.HederaConfig{Topics: []string{"0.0.2001"}}, false, nil, 0, false},
+		{"receipts", 1, config.HederaConfig{Topics: []string{"0.0.2001", "0.0.2002"}, WaitForReceipt: true}, false, nil, 2, false},
+		{"receipts per chunk", 10, config.HederaConfig{Topics: []string{"0.0.2001"}, WaitForReceipt: true}, false, nil, -1, false},
+		{"compressed", 10, config.HederaConfig{Topics: []string{"0.0.2001"}, WaitForReceipt: true, Compress: true}, false, nil, 1, false},
+		{"explicit proof", 1, config.HederaConfig{Topics: []string{"0.0.2001"}}, true, nil, 1, false},
+		{"no receipt", 1, config.HederaConfig{Topics: []string{"0.0.2001"}, WaitForReceipt: true}, false, errors.New("timed out"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wrapper := testutil.AnnotationMessage(testutil.Keys(tt.annotations)...)
+			list, _ := wrapper.AnnotationList()
+
+			p := newTestPublisher(t, tt.cfg, nil, nil)
+			var submitted []string
+			var payload []byte
+			sequence := uint64(0)
+			p.submit = func(topicId hedera.TopicID, b []byte) ([]hedera.TransactionResponse, error) {
+				responses := submitChunks(b)
+				for range responses {
+					submitted = append(submitted, topicId.String())
+				}
+				payload = b
+				return responses, nil
+			}
+			p.confirm = func(topic string, _ hedera.TransactionResponse) (message.ConsensusProof, hedera.Hbar, error) {
+				sequence++
+				return message.ConsensusProof{TopicId: topic, Sequence: sequence, RunningHash: []byte{1, 2}}, hedera.Hbar{}, tt.confirmErr
+			}
+
+			var proofs []message.ConsensusProof
+			var err error
+			if tt.withProof {
+				var provider interfaces.ProofProvider = p
+				proofs, err = provider.PublishWithProof(wrapper)
+			} else {
+				err = p.Publish(wrapper)
+			}
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+
+			var published message.PublishWrapper
+			b, err := decompress(payload)
+			if err == nil {
+				err = json.Unmarshal(b, &published)
+			}
+			if got, ok := published.AnnotationList(); err != nil || !ok || len(got.Items) != len(list.Items) ||
+				tt.cfg.Compress != bytes.HasPrefix(payload, gzipMagic) {
+				t.Errorf("unexpected payload %x", payload)
+			}
+
+			chunks := len(submitted) / len(tt.cfg.Topics)
+			if (tt.expectProofs == -1 && (chunks < 2 || int(sequence) != chunks)) ||
+				(tt.expectProofs >= 0 && int(sequence) != tt.expectProofs) {
+				t.Errorf("unexpected number of confirmations %d for %d messages", sequence, len(submitted))
+			}
+			for i, proof := range proofs {
+				if proof.Sequence != uint64(i+1) || len(proof.AnnotationIds) != len(list.Items) ||
+					proof.AnnotationIds[0] != list.Items[0].Id.String() {
+					t.Errorf("unexpected proof %v", proof)
+				}
+			}
+		})
+	}
+}
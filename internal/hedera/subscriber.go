@@ -0,0 +1,265 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc/status"
+)
+
+const redeliveryInterval = time.Second
+
+// topicQuery subscribes to the messages of a topic with a consensus timestamp no earlier than start. onNext is
+// called for one message at a time. onDone is called once if the subscription ends by itself, with a nil error when
+// the end of the topic was reached. The returned function cancels the subscription.
+type topicQuery func(topic hedera.TopicID, start time.Time, onNext func(hedera.TopicMessage), onDone func(error)) (func(), error)
+
+// checkpoint identifies the last acknowledged message of a topic.
+type checkpoint struct {
+	Sequence  uint64    `json:"sequence"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+type hederaSubscriber struct {
+	cfg          config.HederaConfig
+	logger       interfaces.Logger
+	hederaClient *hedera.Client
+	query        topicQuery
+
+	mutex       sync.Mutex // guards checkpoints and serializes calls to the handler
+	checkpoints map[string]checkpoint
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewHederaSubscriber prepares a subscriber reading the configured topics from the mirror node. No operator account
+// is required.
+func NewHederaSubscriber(cfg config.HederaConfig, logger interfaces.Logger) (interfaces.StreamSubscriber, error) {
+	if len(cfg.Topics) == 0 {
+		return nil, errors.New("at least one Hedera topic must be provided")
+	}
+	for _, topic := range cfg.Topics {
+		_, err := hedera.TopicIDFromString(topic)
+		if err != nil {
+			return nil, fmt.Errorf("invalid topic %s: %w", topic, err)
+		}
+	}
+	client, err := clientForNetwork(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p := hederaSubscriber{
+		cfg:          cfg,
+		logger:       logger,
+		hederaClient: client,
+		checkpoints:  make(map[string]checkpoint),
+		closed:       make(chan struct{}),
+	}
+	p.query = p.mirrorQuery
+	return &p, nil
+}
+
+// Connect restores the last acknowledged position of each topic, if any.
+func (p *hederaSubscriber) Connect() error {
+	if p.cfg.OffsetPath == "" {
+		return nil
+	}
+	b, err := os.ReadFile(p.cfg.OffsetPath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	err = json.Unmarshal(b, &p.checkpoints)
+	if err != nil {
+		return fmt.Errorf("invalid checkpoints in %s: %w", p.cfg.OffsetPath, err)
+	}
+	return nil
+}
+
+// Subscribe delivers the messages of all configured topics in consensus order per topic, starting after the last
+// acknowledged message or from the beginning of the topic. Chunked messages are reassembled by the SDK and compressed
+// ones decompressed. Each delivery carries the topic sequence number and consensus timestamp, those of the last chunk
+// for chunked messages. A rejected message is redelivered every second until it is accepted. Messages that cannot be
+// decoded are reported to onError and skipped.
+func (p *hederaSubscriber) Subscribe(ctx context.Context, handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) error {
+	if onError == nil {
+		onError = func(err error) {
+			p.logger.Error(err.Error())
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	failed := make(chan error, len(p.cfg.Topics))
+
+	var cancels []func()
+	defer func() {
+		for _, c := range cancels {
+			c()
+		}
+	}()
+	for _, topic := range p.cfg.Topics {
+		topicId, _ := hedera.TopicIDFromString(topic)
+
+		p.mutex.Lock()
+		last, resumed := p.checkpoints[topic]
+		p.mutex.Unlock()
+		var start time.Time
+		if resumed {
+			start = last.Timestamp.Add(time.Nanosecond)
+		}
+
+		onNext := func(m hedera.TopicMessage) {
+			p.receive(ctx, topic, m, handler, onError)
+		}
+		onDone := func(err error) {
+			if err != nil {
+				err = fmt.Errorf("subscription to topic %s failed: %w", topic, err)
+			}
+			failed <- err
+		}
+		c, err := p.query(topicId, start, onNext, onDone)
+		if err != nil {
+			return err
+		}
+		cancels = append(cancels, c)
+	}
+
+	for completed := 0; completed < len(p.cfg.Topics); completed++ {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-p.closed:
+			return nil
+		case err := <-failed:
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close stops a running Subscribe and closes the client.
+func (p *hederaSubscriber) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+	})
+	return p.hederaClient.Close()
+}
+
+// receive hands a message to handler until it is accepted and then records it as the last acknowledged message of
+// the topic.
+func (p *hederaSubscriber) receive(ctx context.Context, topic string, m hedera.TopicMessage,
+	handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	// Already acknowledged before resuming
+	if last, ok := p.checkpoints[topic]; ok && m.SequenceNumber <= last.Sequence {
+		return
+	}
+
+	payload, err := decompress(m.Contents)
+	if err != nil {
+		onError(&message.DeliveryError{Source: topic, Payload: m.Contents, Err: err})
+		p.acknowledge(topic, m)
+		return
+	}
+
+	deliveries, err := message.NewDeliveries(payload, topic)
+	if err != nil {
+		onError(&message.DeliveryError{Source: topic, Payload: payload, Err: err})
+	}
+	for _, d := range deliveries {
+		d.Sequence = m.SequenceNumber
+		d.Timestamp = m.ConsensusTimestamp
+		for {
+			err = handler(ctx, d)
+			if err == nil {
+				break
+			}
+			onError(&message.DeliveryError{Source: topic, Payload: payload, Err: err})
+			select {
+			case <-ctx.Done():
+				return
+			case <-p.closed:
+				return
+			case <-time.After(redeliveryInterval):
+			}
+		}
+	}
+
+	p.acknowledge(topic, m)
+}
+
+// acknowledge records the message as the last one read from the topic. Must be called with the mutex held.
+func (p *hederaSubscriber) acknowledge(topic string, m hedera.TopicMessage) {
+	p.checkpoints[topic] = checkpoint{Sequence: m.SequenceNumber, Timestamp: m.ConsensusTimestamp}
+	err := p.checkpoint()
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed to record position of topic %s: %s", topic, err.Error()))
+	}
+}
+
+// checkpoint records the acknowledged positions. Must be called with the mutex held.
+func (p *hederaSubscriber) checkpoint() error {
+	if p.cfg.OffsetPath == "" {
+		return nil
+	}
+	b, _ := json.Marshal(p.checkpoints)
+	return atomicfile.Write(p.cfg.OffsetPath, b, 0600)
+}
+
+// mirrorQuery subscribes to the topic on the mirror node. The SDK retries transient failures itself.
+func (p *hederaSubscriber) mirrorQuery(topic hedera.TopicID, start time.Time, onNext func(hedera.TopicMessage),
+	onDone func(error)) (func(), error) {
+	query := hedera.NewTopicMessageQuery().
+		SetTopicID(topic).
+		SetErrorHandler(func(stat status.Status) {
+			onDone(stat.Err())
+		}).
+		SetCompletionHandler(func() {
+			onDone(nil)
+		})
+	if !start.IsZero() {
+		query.SetStartTime(start)
+	}
+
+	handle, err := query.Subscribe(p.hederaClient, onNext)
+	if err != nil {
+		return nil, err
+	}
+	return handle.Unsubscribe, nil
+}
@@ -0,0 +1,173 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// replay returns a query which plays back messages and then completes with err.
+func replay(starts *[]time.Time, messages []hedera.TopicMessage, err error) topicQuery {
+	return func(_ hedera.TopicID, start time.Time, onNext func(hedera.TopicMessage), onDone func(error)) (func(), error) {
+		*starts = append(*starts, start)
+		for _, m := range messages {
+			onNext(m)
+		}
+		onDone(err)
+		return func() {}, nil
+	}
+}
+
+func topicMessage(t *testing.T, sequence uint64, key string) hedera.TopicMessage {
+	b, err := json.Marshal(testutil.AnnotationMessage(key))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return hedera.TopicMessage{
+		ConsensusTimestamp: time.Unix(1700000000, int64(sequence)).UTC(),
+		Contents:           b,
+		SequenceNumber:     sequence,
+	}
+}
+
+func TestHederaSubscriber(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.HederaConfig{
+		NetType:    contracts.Local,
+		Topics:     []string{"0.0.1001"},
+		OffsetPath: filepath.Join(t.TempDir(), "hedera.offset"),
+	}
+
+	first := []hedera.TopicMessage{
+		topicMessage(t, 1, "key1"),
+		{ConsensusTimestamp: time.Unix(1700000000, 2).UTC(), Contents: []byte("not json"), SequenceNumber: 2},
+		topicMessage(t, 3, "key3"),
+	}
+	// The mirror node may replay messages that were already acknowledged
+	second := []hedera.TopicMessage{topicMessage(t, 3, "key3"), topicMessage(t, 4, "key4")}
+
+	tests := []struct {
+		name         string
+		messages     []hedera.TopicMessage
+		err          error
+		expectStart  time.Time
+		expectKeys   []string
+		expectErrors int
+		expectError  bool
+	}{
+		{"from the beginning", first, nil, time.Time{}, []string{"key1", "key3"}, 1, false},
+		{"resume after last sequence", second, nil, first[2].ConsensusTimestamp.Add(time.Nanosecond), []string{"key4"}, 0, false},
+		{"subscription failed", nil, errors.New("unavailable"), second[1].ConsensusTimestamp.Add(time.Nanosecond), nil, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := NewHederaSubscriber(cfg, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			var starts []time.Time
+			s.(*hederaSubscriber).query = replay(&starts, tt.messages, tt.err)
+			err = s.Connect()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			defer s.Close()
+
+			var received []message.Delivery
+			var errs []error
+			err = s.Subscribe(context.Background(), func(_ context.Context, d message.Delivery) error {
+				received = append(received, d)
+				return nil
+			}, func(err error) {
+				errs = append(errs, err)
+			})
+			test.CheckError(err, tt.expectError, tt.name, t)
+
+			if len(starts) != 1 || !starts[0].Equal(tt.expectStart) {
+				t.Errorf("unexpected start times %v", starts)
+			}
+			if len(received) != len(tt.expectKeys) || len(errs) != tt.expectErrors {
+				t.Fatalf("unexpected deliveries %v errors %v", received, errs)
+			}
+			for i, d := range received {
+				if d.Annotations.Items[0].Key != tt.expectKeys[i] || d.Source != "0.0.1001" {
+					t.Errorf("unexpected delivery %v", d)
+				}
+				if d.Sequence == 0 || !d.Timestamp.Equal(time.Unix(1700000000, int64(d.Sequence))) {
+					t.Errorf("unexpected sequence %d timestamp %s", d.Sequence, d.Timestamp)
+				}
+			}
+		})
+	}
+}
+
+func TestHederaSubscriberCompressed(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.HederaConfig{NetType: contracts.Local, Topics: []string{"0.0.1001"}}
+
+	b, _ := json.Marshal(testutil.AnnotationMessage(testutil.Keys(10)...))
+	compressed, err := compress(b)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	messages := []hedera.TopicMessage{
+		topicMessage(t, 1, "plain"),
+		// Reassembled by the SDK from chunks, carrying the position of the last one
+		{ConsensusTimestamp: time.Unix(1700000000, 3).UTC(), Contents: compressed, SequenceNumber: 3,
+			Chunks: make([]hedera.TopicMessageChunk, 2)},
+		{ConsensusTimestamp: time.Unix(1700000000, 4).UTC(), Contents: append([]byte{}, gzipMagic...), SequenceNumber: 4},
+	}
+
+	s, err := NewHederaSubscriber(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var starts []time.Time
+	sub := s.(*hederaSubscriber)
+	sub.query = replay(&starts, messages, nil)
+
+	var received []message.Delivery
+	var errs []error
+	err = s.Subscribe(context.Background(), func(_ context.Context, d message.Delivery) error {
+		received = append(received, d)
+		return nil
+	}, func(err error) {
+		errs = append(errs, err)
+	})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(received) != 2 || received[0].Annotations.Items[0].Key != "plain" || len(received[1].Annotations.Items) != 10 ||
+		len(errs) != 1 {
+		t.Fatalf("unexpected deliveries %v errors %v", received, errs)
+	}
+	if received[1].Sequence != 3 || sub.checkpoints["0.0.1001"].Sequence != 4 {
+		t.Errorf("unexpected sequence %d position %v", received[1].Sequence, sub.checkpoints)
+	}
+}
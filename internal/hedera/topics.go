@@ -0,0 +1,182 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+)
+
+// provision drops topics that no longer exist and creates new ones until TopicCount are available. Created topics
+// are recorded at TopicsPath and reused by later runs.
+func (p *HederaPublisher) provision() error {
+	count := p.cfg.TopicCount
+	if count == 0 {
+		count = 1
+	}
+	saved, err := readTopics(p.cfg.TopicsPath)
+	if err != nil {
+		return err
+	}
+
+	configured := make(map[string]bool, len(p.cfg.Topics))
+	var topics []string
+	for _, topic := range append(append([]string{}, p.cfg.Topics...), saved...) {
+		if configured[topic] {
+			continue
+		}
+		configured[topic] = true
+
+		topicId, err := hedera.TopicIDFromString(topic)
+		if err != nil {
+			return err
+		}
+		exists, err := p.topicExists(topicId)
+		if err != nil {
+			return fmt.Errorf("failed to look up topic %s: %w", topic, err)
+		}
+		if !exists {
+			p.logger.Error(fmt.Sprintf("topic %s no longer exists and will be replaced", topic))
+			continue
+		}
+		topics = append(topics, topic)
+	}
+
+	var created []string
+	for _, topic := range saved {
+		if contains(topics, topic) && !contains(p.cfg.Topics, topic) {
+			created = append(created, topic)
+		}
+	}
+	for len(topics) < count {
+		topicId, err := p.createTopic()
+		if err != nil {
+			return fmt.Errorf("failed to create topic: %w", err)
+		}
+		p.logger.Write(slog.LevelInfo, fmt.Sprintf("created topic %s", topicId.String()))
+		topics = append(topics, topicId.String())
+		created = append(created, topicId.String())
+
+		// Record each topic straight away so that it is not lost if creating the next one fails
+		err = writeTopics(p.cfg.TopicsPath, created)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.cfg.Topics = topics
+	return writeTopics(p.cfg.TopicsPath, created)
+}
+
+// queryTopic reports whether the topic exists.
+func (p *HederaPublisher) queryTopic(topicId hedera.TopicID) (bool, error) {
+	_, err := hedera.NewTopicInfoQuery().
+		SetTopicID(topicId).
+		Execute(p.hederaClient)
+	if err == nil {
+		return true, nil
+	}
+
+	var precheck hedera.ErrHederaPreCheckStatus
+	if errors.As(err, &precheck) &&
+		(precheck.Status == hedera.StatusInvalidTopicID || precheck.Status == hedera.StatusTopicExpired) {
+		return false, nil
+	}
+	return false, err
+}
+
+// newTopic creates a topic administered by the operator. If a submit key is configured, only messages signed with it
+// are accepted by the topic. The creation fee counts against the budget.
+func (p *HederaPublisher) newTopic() (hedera.TopicID, error) {
+	tx := hedera.NewTopicCreateTransaction().
+		SetAdminKey(p.hederaClient.GetOperatorPublicKey()).
+		SetTopicMemo(p.cfg.TopicMemo)
+	if p.submitKey != nil {
+		tx.SetSubmitKey(p.submitKey.PublicKey())
+	}
+
+	if p.budget != nil {
+		if reason, exhausted := p.budget.check(); exhausted {
+			return hedera.TopicID{}, fmt.Errorf("%w, %s", ErrBudgetExhausted, reason)
+		}
+	}
+
+	resp, err := tx.Execute(p.hederaClient)
+	if err != nil {
+		return hedera.TopicID{}, err
+	}
+	var receipt hedera.TransactionReceipt
+	if p.budget != nil {
+		record, err := resp.GetRecord(p.hederaClient)
+		if err != nil {
+			return hedera.TopicID{}, err
+		}
+		p.budget.charge(record.TransactionFee)
+		receipt = record.Receipt
+	} else {
+		receipt, err = resp.GetReceipt(p.hederaClient)
+		if err != nil {
+			return hedera.TopicID{}, err
+		}
+	}
+	if receipt.TopicID == nil {
+		return hedera.TopicID{}, fmt.Errorf("no topic ID in receipt of transaction %s", resp.TransactionID.String())
+	}
+	return *receipt.TopicID, nil
+}
+
+func readTopics(path string) ([]string, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var topics []string
+	err = json.Unmarshal(b, &topics)
+	if err != nil {
+		return nil, fmt.Errorf("invalid topics in %s: %w", path, err)
+	}
+	return topics, nil
+}
+
+// writeTopics records the topics at path.
+func writeTopics(path string, topics []string) error {
+	if path == "" {
+		return nil
+	}
+	b, _ := json.Marshal(topics)
+	return atomicfile.Write(path, b, 0600)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package hedera
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashgraph/hedera-sdk-go/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// network is a fake consensus service tracking which topics exist
+type network struct {
+	topics  map[string]bool
+	next    uint64
+	failing bool
+}
+
+func (n *network) exists(topicId hedera.TopicID) (bool, error) {
+	return n.topics[topicId.String()], nil
+}
+
+func (n *network) create() (hedera.TopicID, error) {
+	if n.failing {
+		return hedera.TopicID{}, errors.New("insufficient payer balance")
+	}
+	n.next++
+	topicId := hedera.TopicID{Topic: 3000 + n.next}
+	n.topics[topicId.String()] = true
+	return topicId, nil
+}
+
+type recordingStream struct {
+	connected bool
+	closed    bool
+	published []message.PublishWrapper
+}
+
+func (s *recordingStream) Connect() error {
+	s.connected = true
+	return nil
+}
+
+func (s *recordingStream) Publish(msg message.PublishWrapper) error {
+	s.published = append(s.published, msg)
+	return nil
+}
+
+func (s *recordingStream) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestHederaPublisherProvision(t *testing.T) {
+	tests := []struct {
+		name         string
+		configured   []string
+		saved        []string
+		existing     []string
+		count        int
+		failing      bool
+		expectTopics []string
+		expectSaved  []string
+		expectError  bool
+	}{
+		{"create", nil, nil, nil, 0, false, []string{"0.0.3001"}, []string{"0.0.3001"}, false},
+		{"create several", nil, nil, nil, 2, false, []string{"0.0.3001", "0.0.3002"}, []string{"0.0.3001", "0.0.3002"}, false},
+		{"reuse saved", nil, []string{"0.0.2500"}, []string{"0.0.2500"}, 0, false, []string{"0.0.2500"}, []string{"0.0.2500"}, false},
+		{"replace deleted", nil, []string{"0.0.2500"}, nil, 0, false, []string{"0.0.3001"}, []string{"0.0.3001"}, false},
+		{"configured", []string{"0.0.2001"}, nil, []string{"0.0.2001"}, 2, false, []string{"0.0.2001", "0.0.3001"}, []string{"0.0.3001"}, false},
+		{"configured deleted", []string{"0.0.2001"}, nil, nil, 0, false, []string{"0.0.3001"}, []string{"0.0.3001"}, false},
+		{"enough topics", []string{"0.0.2001", "0.0.2002"}, nil, []string{"0.0.2001", "0.0.2002"}, 0, false, []string{"0.0.2001", "0.0.2002"}, nil, false},
+		{"creation fails", nil, nil, nil, 0, true, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "topics.json")
+			if tt.saved != nil {
+				b, _ := json.Marshal(tt.saved)
+				err := os.WriteFile(path, b, 0600)
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+
+			n := network{topics: make(map[string]bool), failing: tt.failing}
+			for _, topic := range tt.existing {
+				n.topics[topic] = true
+			}
+			cfg := config.HederaConfig{Topics: tt.configured, CreateTopics: true, TopicCount: tt.count, TopicsPath: path}
+			p := newTestPublisher(t, cfg, nil, nil)
+			p.topicExists = n.exists
+			p.createTopic = n.create
+
+			err := p.Connect()
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(p.cfg.Topics, tt.expectTopics) {
+				t.Errorf("unexpected topics %v", p.cfg.Topics)
+			}
+			saved, err := readTopics(path)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if len(saved) != len(tt.expectSaved) || (len(saved) > 0 && !reflect.DeepEqual(saved, tt.expectSaved)) {
+				t.Errorf("unexpected saved topics %v", saved)
+			}
+
+			// A restart reuses the topics rather than creating new ones
+			restarted := newTestPublisher(t, cfg, nil, nil)
+			restarted.topicExists = n.exists
+			restarted.createTopic = func() (hedera.TopicID, error) {
+				return hedera.TopicID{}, fmt.Errorf("unexpected topic creation")
+			}
+			err = restarted.Connect()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if !reflect.DeepEqual(restarted.cfg.Topics, tt.expectTopics) {
+				t.Errorf("unexpected topics after restart %v", restarted.cfg.Topics)
+			}
+		})
+	}
+}
+
+func TestHederaPublisherBroadcast(t *testing.T) {
+	tests := []struct {
+		name        string
+		broadcast   bool
+		stream      *recordingStream
+		expectError bool
+	}{
+		{"broadcast", true, &recordingStream{}, false},
+		{"no broadcast", false, nil, false},
+		{"missing broadcast stream", true, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.HederaConfig{Topics: []string{"0.0.2001", "0.0.2002"}, ShouldBroadcastTopic: tt.broadcast}
+			if tt.expectError {
+				logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+				_, err := NewHederaPublisher(cfg, nil, nil, logger)
+				test.CheckError(err, tt.expectError, tt.name, t)
+				return
+			}
+
+			var broadcast interfaces.StreamProvider
+			if tt.stream != nil {
+				broadcast = tt.stream
+			}
+			p := newTestPublisher(t, cfg, broadcast, nil)
+
+			err := p.Connect()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			err = p.Close()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if tt.stream == nil {
+				return
+			}
+
+			if !tt.stream.connected || !tt.stream.closed || len(tt.stream.published) != 4 {
+				t.Fatalf("unexpected broadcast %+v", tt.stream)
+			}
+			for i, msg := range tt.stream.published {
+				action := message.ActionBroadcast
+				if i >= 2 {
+					action = message.ActionEndStream
+				}
+				if msg.Action != action || string(msg.Content) != cfg.Topics[i%2] {
+					t.Errorf("unexpected broadcast message %s %s", msg.Action, string(msg.Content))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package kafka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/IBM/sarama"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+type kafkaPublisher struct {
+	cfg      config.KafkaConfig
+	logger   interfaces.Logger
+	client   *sarama.Config
+	producer sarama.SyncProducer
+}
+
+// NewKafkaPublisher validates the configuration and prepares a producer. No connection to the brokers is made
+// until Connect is called.
+func NewKafkaPublisher(cfg config.KafkaConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if len(cfg.Topics) == 0 {
+		return nil, errors.New("at least one Kafka topic must be provided")
+	}
+	client, err := newSaramaConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p := kafkaPublisher{
+		cfg:    cfg,
+		logger: logger,
+		client: client,
+	}
+	return &p, nil
+}
+
+func (p *kafkaPublisher) Connect() error {
+	producer, err := sarama.NewSyncProducer(p.cfg.Brokers, p.client)
+	if err != nil {
+		return err
+	}
+	p.producer = producer
+	return nil
+}
+
+// Publish sends the message to every configured topic. The message key is the hash of the annotated data so that
+// all annotations for one datum land in the same partition. Messages without annotations are sent without a key.
+func (p *kafkaPublisher) Publish(msg message.PublishWrapper) error {
+	if p.producer == nil {
+		return errors.New("kafka publisher is not connected")
+	}
+
+	b, _ := json.Marshal(msg)
+
+	var key sarama.Encoder
+	if k := msg.DataKey(); k != "" {
+		key = sarama.StringEncoder(k)
+	}
+
+	batch := make([]*sarama.ProducerMessage, 0, len(p.cfg.Topics))
+	for _, topic := range p.cfg.Topics {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, topic %s %s", topic, string(b)))
+		batch = append(batch, &sarama.ProducerMessage{
+			Topic: topic,
+			Key:   key,
+			Value: sarama.ByteEncoder(b),
+		})
+	}
+
+	err := p.producer.SendMessages(batch)
+	if err != nil {
+		var errs sarama.ProducerErrors
+		if errors.As(err, &errs) {
+			failed := make([]error, 0, len(errs))
+			for _, e := range errs {
+				failed = append(failed, fmt.Errorf("topic %s: %w", e.Msg.Topic, e.Err))
+			}
+			return errors.Join(failed...)
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *kafkaPublisher) Close() error {
+	if p.producer == nil {
+		return nil
+	}
+	return p.producer.Close()
+}
+
+func newSaramaConfig(cfg config.KafkaConfig) (*sarama.Config, error) {
+	if len(cfg.Brokers) == 0 {
+		return nil, errors.New("at least one Kafka broker must be provided")
+	}
+
+	c := sarama.NewConfig()
+	if cfg.ClientId != "" {
+		c.ClientID = cfg.ClientId
+	}
+	if cfg.Version != "" {
+		v, err := sarama.ParseKafkaVersion(cfg.Version)
+		if err != nil {
+			return nil, err
+		}
+		c.Version = v
+	}
+
+	// Required by the synchronous producer
+	c.Producer.Return.Successes = true
+	c.Producer.Return.Errors = true
+
+	switch cfg.Acks {
+	case "", config.KafkaAcksAll:
+		c.Producer.RequiredAcks = sarama.WaitForAll
+	case config.KafkaAcksLeader:
+		c.Producer.RequiredAcks = sarama.WaitForLocal
+	case config.KafkaAcksNone:
+		c.Producer.RequiredAcks = sarama.NoResponse
+	default:
+		return nil, fmt.Errorf("invalid Kafka acks value %s", cfg.Acks)
+	}
+
+	switch cfg.Compression {
+	case "", "none":
+		c.Producer.Compression = sarama.CompressionNone
+	case "gzip":
+		c.Producer.Compression = sarama.CompressionGZIP
+	case "snappy":
+		c.Producer.Compression = sarama.CompressionSnappy
+	case "lz4":
+		c.Producer.Compression = sarama.CompressionLZ4
+	case "zstd":
+		c.Producer.Compression = sarama.CompressionZSTD
+	default:
+		return nil, fmt.Errorf("invalid Kafka compression value %s", cfg.Compression)
+	}
+
+	if cfg.Idempotent {
+		// The idempotent producer only guarantees exactly-once delivery per partition with a single in-flight request
+		c.Producer.Idempotent = true
+		c.Net.MaxOpenRequests = 1
+		if c.Producer.RequiredAcks != sarama.WaitForAll {
+			return nil, errors.New("idempotent Kafka producer requires acks to be all")
+		}
+	}
+
+	if cfg.Sasl.Mechanism != "" {
+		c.Net.SASL.Enable = true
+		c.Net.SASL.User = cfg.Sasl.User
+		c.Net.SASL.Password = cfg.Sasl.Password
+		c.Net.SASL.Mechanism = sarama.SASLMechanism(cfg.Sasl.Mechanism)
+		switch cfg.Sasl.Mechanism {
+		case sarama.SASLTypePlaintext:
+		case sarama.SASLTypeSCRAMSHA256:
+			c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hash: sha256Generator} }
+		case sarama.SASLTypeSCRAMSHA512:
+			c.Net.SASL.SCRAMClientGeneratorFunc = func() sarama.SCRAMClient { return &scramClient{hash: sha512Generator} }
+		default:
+			return nil, fmt.Errorf("unsupported Kafka SASL mechanism %s", cfg.Sasl.Mechanism)
+		}
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		c.Net.TLS.Enable = true
+		c.Net.TLS.Config = tlsCfg
+	}
+
+	err = c.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return c, nil
+}
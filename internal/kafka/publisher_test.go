@@ -0,0 +1,124 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package kafka
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestKafkaPublisherKeys(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	annotations := testutil.AnnotationMessage("datakey")
+	broadcast := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+
+	tests := []struct {
+		name      string
+		msg       message.PublishWrapper
+		expectKey string
+	}{
+		{"annotations keyed by data hash", annotations, "datakey"},
+		{"broadcast without key", broadcast, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topics: []string{"topic1", "topic2"}}
+			client, err := newSaramaConfig(cfg)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			producer := mocks.NewSyncProducer(t, client)
+			for range cfg.Topics {
+				producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(m *sarama.ProducerMessage) error {
+					var key string
+					if m.Key != nil {
+						k, _ := m.Key.Encode()
+						key = string(k)
+					}
+					if key != tt.expectKey {
+						return fmt.Errorf("unexpected key %q", key)
+					}
+					return nil
+				})
+			}
+
+			p := kafkaPublisher{cfg: cfg, logger: logger, client: client, producer: producer}
+			if err := p.Publish(tt.msg); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			_ = p.Close()
+		})
+	}
+}
+
+func TestKafkaPublisherErrors(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topics: []string{"topic1"}}
+	client, err := newSaramaConfig(cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	producer := mocks.NewSyncProducer(t, client)
+	producer.ExpectSendMessageAndFail(sarama.ErrNotLeaderForPartition)
+
+	p := kafkaPublisher{cfg: cfg, logger: logger, client: client, producer: producer}
+	err = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	if !errors.Is(err, sarama.ErrNotLeaderForPartition) {
+		t.Errorf("expected producer error to be returned, received %v", err)
+	}
+	_ = p.Close()
+}
+
+func TestNewKafkaPublisher(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	tests := []struct {
+		name        string
+		cfg         config.KafkaConfig
+		expectError bool
+	}{
+		{"valid", config.KafkaConfig{Brokers: []string{"localhost:9092"}, Topics: []string{"topic1"}}, false},
+		{"no topics", config.KafkaConfig{Brokers: []string{"localhost:9092"}}, true},
+		{"no brokers", config.KafkaConfig{Topics: []string{"topic1"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := NewKafkaPublisher(tt.cfg, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+
+			// Publishing before Connect fails rather than dereferencing the missing producer
+			err = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+			if err == nil || err.Error() != "kafka publisher is not connected" {
+				t.Errorf("expected not connected error, received %v", err)
+			}
+		})
+	}
+}
@@ -14,20 +14,23 @@
 package mqtt
 
 import (
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
 )
 
 const (
-	waitOnClose    uint          = 250
-	publishTimeout time.Duration = 2000
+	defaultWaitOnClose    int = 250
+	defaultPublishTimeout int = 2000
 )
 
 type mqttPublisher struct {
@@ -36,21 +39,35 @@ type mqttPublisher struct {
 	mqttClient MQTT.Client
 }
 
-func NewMqttPublisher(cfg config.MqttConfig, logger interfaces.Logger) interfaces.StreamProvider {
-	opts := MQTT.NewClientOptions()
-	opts.AddBroker(cfg.Provider.Uri())
-	opts.SetClientID(cfg.ClientId)
-	opts.SetUsername(cfg.User)
-	opts.SetPassword(cfg.Password)
-	opts.SetCleanSession(cfg.Cleanness)
+// NewMqttPublisher prepares a publisher. No connection to the broker is made until Connect is called.
+func NewMqttPublisher(cfg config.MqttConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.PublishTimeout == 0 {
+		cfg.PublishTimeout = defaultPublishTimeout
+	}
+	if cfg.WaitOnClose == 0 {
+		cfg.WaitOnClose = defaultWaitOnClose
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+
+	switch cfg.ProtocolVersion {
+	case 0, 3, 4:
+	case 5:
+		return newMqttV5Publisher(cfg, tlsCfg, logger)
+	default:
+		return nil, fmt.Errorf("unsupported MQTT protocol version %d", cfg.ProtocolVersion)
+	}
 
 	p := mqttPublisher{
 		endpoint:   cfg,
 		logger:     logger,
-		mqttClient: MQTT.NewClient(opts),
+		mqttClient: MQTT.NewClient(clientOptions(cfg, tlsCfg)),
 	}
 
-	return &p
+	return &p, nil
 }
 
 func (p *mqttPublisher) Connect() error {
@@ -67,16 +84,24 @@ func (p *mqttPublisher) Publish(msg message.PublishWrapper) error {
 
 	b, _ := json.Marshal(msg)
 	// publish to all topics
+	var errs []error
 	for _, topic := range p.endpoint.Topics {
 		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, topic %s %s", topic, string(b)))
 		token := p.mqttClient.Publish(topic, byte(p.endpoint.Qos), false, b)
-		token.WaitTimeout(time.Millisecond * publishTimeout)
+		// For QoS 1 and 2 the token only completes once the broker has acknowledged the message
+		if !token.WaitTimeout(time.Millisecond * time.Duration(p.endpoint.PublishTimeout)) {
+			errs = append(errs, fmt.Errorf("topic %s: publish not completed within %dms", topic, p.endpoint.PublishTimeout))
+			continue
+		}
+		if token.Error() != nil {
+			errs = append(errs, fmt.Errorf("topic %s: %w", topic, token.Error()))
+		}
 	}
-	return nil
+	return errors.Join(errs...)
 }
 
 func (p *mqttPublisher) Close() error {
-	p.mqttClient.Disconnect(waitOnClose)
+	p.mqttClient.Disconnect(uint(p.endpoint.WaitOnClose))
 	return nil
 }
 
@@ -89,3 +114,34 @@ func (p *mqttPublisher) reconnect() error {
 	}
 	return nil
 }
+
+// clientOptions configures the MQTT 3.1.1 client shared by the publisher and subscriber.
+func clientOptions(cfg config.MqttConfig, tlsCfg *tls.Config) *MQTT.ClientOptions {
+	opts := MQTT.NewClientOptions()
+	opts.AddBroker(brokerUri(cfg.Provider, tlsCfg != nil))
+	if tlsCfg != nil {
+		opts.SetTLSConfig(tlsCfg)
+	}
+	if cfg.ProtocolVersion == 3 || cfg.ProtocolVersion == 4 {
+		opts.SetProtocolVersion(uint(cfg.ProtocolVersion))
+	}
+	opts.SetClientID(cfg.ClientId)
+	opts.SetUsername(cfg.User)
+	opts.SetPassword(cfg.Password)
+	opts.SetCleanSession(cfg.Cleanness)
+	return opts
+}
+
+// brokerUri returns the URI of the broker. When TLS is configured, plain schemes are upgraded to their secure
+// counterparts since the clients only negotiate TLS for secure schemes.
+func brokerUri(provider config.ServiceInfo, secure bool) string {
+	if secure {
+		switch provider.Protocol {
+		case "", "tcp", "mqtt":
+			provider.Protocol = "ssl"
+		case "ws":
+			provider.Protocol = "wss"
+		}
+	}
+	return provider.Uri()
+}
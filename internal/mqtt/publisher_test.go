@@ -0,0 +1,212 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse/paho.golang/paho"
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// fakeToken completes immediately with err unless timeout is set, in which case it never completes.
+type fakeToken struct {
+	timeout bool
+	err     error
+}
+
+func (t fakeToken) Wait() bool                       { return !t.timeout }
+func (t fakeToken) WaitTimeout(_ time.Duration) bool { return !t.timeout }
+func (t fakeToken) Done() <-chan struct{}            { return make(chan struct{}) }
+func (t fakeToken) Error() error                     { return t.err }
+
+// fakeClient is a connected client returning the token configured for each topic.
+type fakeClient struct {
+	MQTT.Client
+	tokens map[string]fakeToken
+}
+
+func (c fakeClient) IsConnected() bool { return true }
+
+func (c fakeClient) Publish(topic string, _ byte, _ bool, _ interface{}) MQTT.Token {
+	return c.tokens[topic]
+}
+
+func TestMqttPublisherErrors(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	msg := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+
+	tests := []struct {
+		name         string
+		tokens       map[string]fakeToken
+		expectError  bool
+		expectTopics []string // topics expected to be named in the error
+	}{
+		{"all acknowledged", map[string]fakeToken{"a": {}, "b": {}}, false, nil},
+		{"rejected", map[string]fakeToken{"a": {err: errors.New("not authorized")}, "b": {}}, true, []string{"topic a"}},
+		{"timed out", map[string]fakeToken{"a": {}, "b": {timeout: true}}, true, []string{"topic b"}},
+		{"both failed", map[string]fakeToken{"a": {timeout: true}, "b": {err: errors.New("not authorized")}}, true,
+			[]string{"topic a", "topic b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.MqttConfig{Topics: []string{"a", "b"}, Qos: 1, PublishTimeout: 10}
+			p := mqttPublisher{endpoint: cfg, logger: logger, mqttClient: fakeClient{tokens: tt.tokens}}
+
+			err := p.Publish(msg)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			for _, topic := range tt.expectTopics {
+				if err == nil || !strings.Contains(err.Error(), topic) {
+					t.Errorf("expected error for %s, got %v", topic, err)
+				}
+			}
+		})
+	}
+}
+
+func TestMqttPublisherTls(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	tests := []struct {
+		name         string
+		protocol     string
+		tls          config.TlsInfo
+		expectScheme string
+		expectTls    bool
+		expectError  bool
+	}{
+		{"plain", "tcp", config.TlsInfo{}, "tcp", false, false},
+		{"tls over tcp", "tcp", config.TlsInfo{Enabled: true, ServerName: "broker"}, "ssl", true, false},
+		{"tls over websocket", "ws", config.TlsInfo{Enabled: true}, "wss", true, false},
+		{"tls explicit scheme", "mqtts", config.TlsInfo{Enabled: true}, "mqtts", true, false},
+		{"missing client key", "ssl", config.TlsInfo{Enabled: true, CertPath: "./missing.pem"}, "", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.MqttConfig{
+				Provider: config.ServiceInfo{Host: "localhost", Port: 8883, Protocol: tt.protocol},
+				Topics:   []string{"a"},
+				Tls:      tt.tls,
+			}
+			p, err := NewMqttPublisher(cfg, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+
+			opts := p.(*mqttPublisher).mqttClient.OptionsReader()
+			servers := opts.Servers()
+			if len(servers) != 1 || servers[0].Scheme != tt.expectScheme {
+				t.Errorf("unexpected broker %v", servers)
+			}
+			if (opts.TLSConfig() != nil) != tt.expectTls {
+				t.Errorf("unexpected tls config %v", opts.TLSConfig())
+			}
+			if tt.expectTls && opts.TLSConfig().ServerName != tt.tls.ServerName {
+				t.Errorf("unexpected server name %s", opts.TLSConfig().ServerName)
+			}
+		})
+	}
+}
+
+func TestMqttPublisherVersion(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	tests := []struct {
+		name        string
+		version     int
+		expectV5    bool
+		expectError bool
+	}{
+		{"default", 0, false, false},
+		{"3.1.1", 4, false, false},
+		{"5", 5, true, false},
+		{"unsupported", 6, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.MqttConfig{
+				Provider:        config.ServiceInfo{Host: "localhost", Port: 1883, Protocol: "tcp"},
+				Topics:          []string{"a"},
+				ProtocolVersion: tt.version,
+			}
+			p, err := NewMqttPublisher(cfg, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			if _, ok := p.(*mqttV5Publisher); ok != tt.expectV5 {
+				t.Errorf("unexpected publisher %T", p)
+			}
+		})
+	}
+}
+
+func TestMqttPublishProperties(t *testing.T) {
+	list := contracts.AnnotationList{Items: []contracts.Annotation{
+		contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true),
+		contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationPKI, true),
+		contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, false),
+	}}
+	b, _ := json.Marshal(list)
+	annotations := message.PublishWrapper{Action: message.ActionCreate, MessageType: fmt.Sprintf("%T", list), Content: b}
+	broadcast := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+
+	tests := []struct {
+		name         string
+		msg          message.PublishWrapper
+		expiry       int
+		expectExpiry bool
+		expectUser   paho.UserProperties
+	}{
+		{"annotation list", annotations, 0, false, paho.UserProperties{
+			{Key: propertyAction, Value: string(message.ActionCreate)},
+			{Key: propertyMessageType, Value: message.AnnotationListType},
+			{Key: propertyDataKey, Value: "datakey"},
+			{Key: propertyKind, Value: string(contracts.AnnotationTPM)},
+			{Key: propertyKind, Value: string(contracts.AnnotationPKI)},
+		}},
+		{"broadcast with expiry", broadcast, 60, true, paho.UserProperties{
+			{Key: propertyAction, Value: string(message.ActionBroadcast)},
+			{Key: propertyMessageType, Value: "string"},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			properties := publishProperties(tt.msg, tt.expiry)
+			if properties.ContentType != string(contracts.ContentTypeJSON) {
+				t.Errorf("unexpected content type %s", properties.ContentType)
+			}
+			if (properties.MessageExpiry != nil) != tt.expectExpiry ||
+				(tt.expectExpiry && *properties.MessageExpiry != uint32(tt.expiry)) {
+				t.Errorf("unexpected message expiry %v", properties.MessageExpiry)
+			}
+			if fmt.Sprint(properties.User) != fmt.Sprint(tt.expectUser) {
+				t.Errorf("expected user properties %v, got %v", tt.expectUser, properties.User)
+			}
+		})
+	}
+}
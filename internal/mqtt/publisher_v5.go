@@ -0,0 +1,172 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// User property keys set on every MQTT 5 publish so that consumers can route messages without decoding the payload.
+// An annotation list carries one kind property per distinct annotation kind it contains.
+const (
+	propertyAction      = "action"
+	propertyMessageType = "messageType"
+	propertyDataKey     = "dataKey"
+	propertyKind        = "kind"
+
+	defaultConnectTimeout int    = 10000
+	defaultKeepAlive      uint16 = 30
+)
+
+type mqttV5Publisher struct {
+	endpoint config.MqttConfig
+	logger   interfaces.Logger
+	client   autopaho.ClientConfig
+	cm       *autopaho.ConnectionManager
+}
+
+func newMqttV5Publisher(cfg config.MqttConfig, tlsCfg *tls.Config, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	server, err := url.Parse(brokerUri(cfg.Provider, tlsCfg != nil))
+	if err != nil {
+		return nil, err
+	}
+
+	client := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{server},
+		TlsCfg:                        tlsCfg,
+		KeepAlive:                     defaultKeepAlive,
+		CleanStartOnInitialConnection: cfg.Cleanness,
+		ConnectTimeout:                time.Millisecond * time.Duration(defaultConnectTimeout),
+		ConnectUsername:               cfg.User,
+		ConnectPassword:               []byte(cfg.Password),
+		OnConnectError: func(err error) {
+			logger.Error(fmt.Sprintf("mqtt connection to %s failed: %s", server.String(), err.Error()))
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID: cfg.ClientId,
+		},
+	}
+
+	p := mqttV5Publisher{
+		endpoint: cfg,
+		logger:   logger,
+		client:   client,
+	}
+	return &p, nil
+}
+
+// Connect starts the connection manager and waits for the initial connection. The connection manager transparently
+// reconnects should the connection subsequently drop.
+func (p *mqttV5Publisher) Connect() error {
+	cm, err := autopaho.NewConnection(context.Background(), p.client)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(defaultConnectTimeout))
+	defer cancel()
+	err = cm.AwaitConnection(ctx)
+	if err != nil {
+		_ = cm.Disconnect(context.Background())
+		return fmt.Errorf("failed to connect to mqtt broker: %w", err)
+	}
+	p.cm = cm
+	return nil
+}
+
+// Publish sends the message to every configured topic. For QoS 1 and 2 each publish waits for the broker's
+// acknowledgement and a failure reason code is reported as an error.
+func (p *mqttV5Publisher) Publish(msg message.PublishWrapper) error {
+	if p.cm == nil {
+		return errors.New("mqtt publisher is not connected")
+	}
+
+	b, _ := json.Marshal(msg)
+	properties := publishProperties(msg, p.endpoint.MessageExpiry)
+
+	var errs []error
+	for _, topic := range p.endpoint.Topics {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, topic %s %s", topic, string(b)))
+		err := p.publish(&paho.Publish{
+			QoS:        byte(p.endpoint.Qos),
+			Topic:      topic,
+			Properties: properties,
+			Payload:    b,
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("topic %s: %w", topic, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *mqttV5Publisher) Close() error {
+	if p.cm == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.endpoint.WaitOnClose))
+	defer cancel()
+	return p.cm.Disconnect(ctx)
+}
+
+func (p *mqttV5Publisher) publish(packet *paho.Publish) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.endpoint.PublishTimeout))
+	defer cancel()
+
+	_, err := p.cm.Publish(ctx, packet)
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("publish not completed within %dms", p.endpoint.PublishTimeout)
+	}
+	return err
+}
+
+// publishProperties describes the message through MQTT 5 properties.
+func publishProperties(msg message.PublishWrapper, expiry int) *paho.PublishProperties {
+	properties := paho.PublishProperties{ContentType: string(contracts.ContentTypeJSON)}
+	if expiry > 0 {
+		e := uint32(expiry)
+		properties.MessageExpiry = &e
+	}
+
+	properties.User.Add(propertyAction, string(msg.Action))
+	properties.User.Add(propertyMessageType, msg.MessageType)
+	if key := msg.DataKey(); key != "" {
+		properties.User.Add(propertyDataKey, key)
+	}
+	if list, ok := msg.AnnotationList(); ok {
+		seen := make(map[contracts.AnnotationType]bool)
+		for _, a := range list.Items {
+			if !seen[a.Kind] {
+				seen[a.Kind] = true
+				properties.User.Add(propertyKind, string(a.Kind))
+			}
+		}
+	}
+	return &properties
+}
@@ -0,0 +1,158 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+type mqttSubscriber struct {
+	endpoint   config.MqttConfig
+	logger     interfaces.Logger
+	mqttClient MQTT.Client
+	mutex      sync.Mutex // guards callback
+	callback   MQTT.MessageHandler
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+// NewMqttSubscriber prepares a subscriber to the configured topics. The MQTT 3.1.1 client is used regardless of the
+// configured protocol version since MQTT 5 brokers deliver to both.
+func NewMqttSubscriber(cfg config.MqttConfig, logger interfaces.Logger) (interfaces.StreamSubscriber, error) {
+	if len(cfg.Topics) == 0 {
+		return nil, errors.New("at least one MQTT topic must be provided")
+	}
+	if cfg.PublishTimeout == 0 {
+		cfg.PublishTimeout = defaultPublishTimeout
+	}
+	if cfg.WaitOnClose == 0 {
+		cfg.WaitOnClose = defaultWaitOnClose
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+
+	p := mqttSubscriber{
+		endpoint: cfg,
+		logger:   logger,
+		closed:   make(chan struct{}),
+	}
+	opts := clientOptions(cfg, tlsCfg)
+	// Subscriptions do not survive reconnecting with a clean session, so restore them whenever a connection is made
+	opts.SetOnConnectHandler(p.onConnect)
+	p.mqttClient = MQTT.NewClient(opts)
+	return &p, nil
+}
+
+func (p *mqttSubscriber) Connect() error {
+	token := p.mqttClient.Connect()
+	if token.Wait() && token.Error() != nil {
+		return token.Error()
+	}
+	return nil
+}
+
+// Subscribe delivers messages from all configured topics. Messages are acknowledged to the broker once handler
+// returns. MQTT has no negative acknowledgement, so a rejected message is reported to onError and not redelivered.
+func (p *mqttSubscriber) Subscribe(ctx context.Context, handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) error {
+	if onError == nil {
+		onError = func(err error) {
+			p.logger.Error(err.Error())
+		}
+	}
+
+	callback := func(_ MQTT.Client, m MQTT.Message) {
+		deliveries, err := message.NewDeliveries(m.Payload(), m.Topic())
+		if err != nil {
+			onError(&message.DeliveryError{Source: m.Topic(), Payload: m.Payload(), Err: err})
+		}
+		for _, d := range deliveries {
+			err = handler(ctx, d)
+			if err != nil {
+				onError(&message.DeliveryError{Source: m.Topic(), Payload: m.Payload(), Err: err})
+			}
+		}
+	}
+
+	p.mutex.Lock()
+	p.callback = callback
+	p.mutex.Unlock()
+
+	err := p.subscribe(callback)
+	if err != nil {
+		return err
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-p.closed:
+	}
+
+	p.mutex.Lock()
+	p.callback = nil
+	p.mutex.Unlock()
+	if p.mqttClient.IsConnected() {
+		token := p.mqttClient.Unsubscribe(p.endpoint.Topics...)
+		token.WaitTimeout(time.Millisecond * time.Duration(p.endpoint.WaitOnClose))
+	}
+	return nil
+}
+
+func (p *mqttSubscriber) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+	})
+	p.mqttClient.Disconnect(uint(p.endpoint.WaitOnClose))
+	return nil
+}
+
+func (p *mqttSubscriber) subscribe(callback MQTT.MessageHandler) error {
+	filters := make(map[string]byte, len(p.endpoint.Topics))
+	for _, topic := range p.endpoint.Topics {
+		filters[topic] = byte(p.endpoint.Qos)
+	}
+
+	token := p.mqttClient.SubscribeMultiple(filters, callback)
+	if !token.WaitTimeout(time.Millisecond * time.Duration(p.endpoint.PublishTimeout)) {
+		return fmt.Errorf("subscribe not completed within %dms", p.endpoint.PublishTimeout)
+	}
+	return token.Error()
+}
+
+func (p *mqttSubscriber) onConnect(_ MQTT.Client) {
+	p.mutex.Lock()
+	callback := p.callback
+	p.mutex.Unlock()
+	if callback == nil {
+		return
+	}
+
+	err := p.subscribe(callback)
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed to restore subscriptions after reconnect: %s", err.Error()))
+	}
+}
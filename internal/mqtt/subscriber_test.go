@@ -0,0 +1,137 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package mqtt
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	MQTT "github.com/eclipse/paho.mqtt.golang"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// fakeMessage is a received message carrying only a topic and payload.
+type fakeMessage struct {
+	MQTT.Message
+	topic   string
+	payload []byte
+}
+
+func (m fakeMessage) Topic() string   { return m.topic }
+func (m fakeMessage) Payload() []byte { return m.payload }
+
+// subscribingClient hands out the callback of the most recent subscription.
+type subscribingClient struct {
+	fakeClient
+	filters   chan map[string]byte
+	callbacks chan MQTT.MessageHandler
+}
+
+func (c subscribingClient) SubscribeMultiple(filters map[string]byte, callback MQTT.MessageHandler) MQTT.Token {
+	c.filters <- filters
+	c.callbacks <- callback
+	return fakeToken{}
+}
+
+func (c subscribingClient) Unsubscribe(_ ...string) MQTT.Token {
+	return fakeToken{}
+}
+
+func TestMqttSubscriber(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	client := subscribingClient{
+		filters:   make(chan map[string]byte, 1),
+		callbacks: make(chan MQTT.MessageHandler, 1),
+	}
+	cfg := config.MqttConfig{Topics: []string{"a", "b"}, Qos: 1, PublishTimeout: 10, WaitOnClose: 10}
+	s := mqttSubscriber{endpoint: cfg, logger: logger, mqttClient: client, closed: make(chan struct{})}
+
+	var received []message.Delivery
+	var errs []error
+	handler := func(_ context.Context, d message.Delivery) error {
+		if d.Action == message.ActionMutate {
+			return errors.New("rejected")
+		}
+		received = append(received, d)
+		return nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Subscribe(ctx, handler, func(err error) { errs = append(errs, err) })
+	}()
+
+	var callback MQTT.MessageHandler
+	select {
+	case filters := <-client.filters:
+		if len(filters) != 2 || filters["a"] != 1 || filters["b"] != 1 {
+			t.Errorf("unexpected subscription %v", filters)
+		}
+		callback = <-client.callbacks
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for subscription")
+	}
+
+	wrapper := testutil.AnnotationMessage("key")
+	created, _ := json.Marshal(wrapper)
+	wrapper.Action = message.ActionMutate
+	mutated, _ := json.Marshal(wrapper)
+
+	// Callbacks are invoked sequentially by the client
+	callback(client, fakeMessage{topic: "a", payload: created})
+	callback(client, fakeMessage{topic: "b", payload: mutated})
+	callback(client, fakeMessage{topic: "b", payload: []byte("not json")})
+
+	// Reconnecting restores the subscription with the same callback
+	s.onConnect(client)
+	select {
+	case <-client.filters:
+		<-client.callbacks
+	case <-time.After(5 * time.Second):
+		t.Fatalf("subscription not restored")
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(received) != 1 || received[0].Source != "a" || len(received[0].Annotations.Items) != 1 ||
+		received[0].Annotations.Items[0].Key != "key" {
+		t.Errorf("unexpected deliveries %v", received)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected a rejection and a decoding error, got %v", errs)
+	}
+	var deliveryErr *message.DeliveryError
+	if !errors.As(errs[0], &deliveryErr) || deliveryErr.Source != "b" {
+		t.Errorf("unexpected error %v", errs[0])
+	}
+
+	// No subscription is restored once the subscriber has stopped
+	s.onConnect(client)
+	select {
+	case <-client.filters:
+		t.Errorf("unexpected subscription after stopping")
+	default:
+	}
+}
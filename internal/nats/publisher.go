@@ -0,0 +1,183 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package nats
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultPublishTimeout int = 2000
+	defaultWaitOnClose    int = 5000
+)
+
+type natsPublisher struct {
+	cfg     config.NatsConfig
+	logger  interfaces.Logger
+	options []nats.Option
+	conn    *nats.Conn
+	js      nats.JetStreamContext
+	closed  chan struct{} // closed by the ClosedHandler once the connection is closed
+}
+
+// NewNatsPublisher validates the configuration and prepares a publisher. No connection to the server is made
+// until Connect is called.
+func NewNatsPublisher(cfg config.NatsConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if len(cfg.Subjects) == 0 {
+		return nil, errors.New("at least one NATS subject must be provided")
+	}
+	if cfg.PublishTimeout == 0 {
+		cfg.PublishTimeout = defaultPublishTimeout
+	}
+	if cfg.WaitOnClose == 0 {
+		cfg.WaitOnClose = defaultWaitOnClose
+	}
+
+	options := []nats.Option{nats.Name(cfg.Name)}
+	if cfg.User != "" {
+		options = append(options, nats.UserInfo(cfg.User, cfg.Password))
+	}
+	if cfg.Token != "" {
+		options = append(options, nats.Token(cfg.Token))
+	}
+	if cfg.CredsPath != "" {
+		options = append(options, nats.UserCredentials(cfg.CredsPath))
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	if tlsCfg != nil {
+		options = append(options, nats.Secure(tlsCfg))
+	}
+
+	p := natsPublisher{
+		cfg:     cfg,
+		logger:  logger,
+		options: options,
+	}
+	return &p, nil
+}
+
+func (p *natsPublisher) Connect() error {
+	closed := make(chan struct{})
+	options := append([]nats.Option{}, p.options...)
+	options = append(options, nats.ClosedHandler(func(*nats.Conn) {
+		close(closed)
+	}))
+	conn, err := nats.Connect(p.cfg.Provider.Uri(), options...)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	p.closed = closed
+
+	if p.cfg.JetStream {
+		js, err := conn.JetStream(nats.MaxWait(time.Millisecond * time.Duration(p.cfg.PublishTimeout)))
+		if err != nil {
+			conn.Close()
+			return err
+		}
+		p.js = js
+	}
+	return nil
+}
+
+// Publish sends the message to every configured subject. With JetStream each publish waits for the server's
+// acknowledgement and carries a Nats-Msg-Id derived from the annotation ULID so that retries are deduplicated.
+// With core NATS the connection is flushed so that errors reported by the server are surfaced.
+func (p *natsPublisher) Publish(msg message.PublishWrapper) error {
+	if p.conn == nil {
+		return errors.New("nats publisher is not connected")
+	}
+
+	b, _ := json.Marshal(msg)
+	id := msg.MessageId()
+
+	var errs []error
+	for _, subject := range p.cfg.Subjects {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, subject %s %s", subject, string(b)))
+
+		if p.js == nil {
+			err := p.conn.Publish(subject, b)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("subject %s: %w", subject, err))
+			}
+			continue
+		}
+
+		var opts []nats.PubOpt
+		if id != "" {
+			// Deduplication happens per JetStream stream, so distinguish the copies sent to each subject in case
+			// several subjects are captured by the same stream
+			msgId := id
+			if len(p.cfg.Subjects) > 1 {
+				msgId = fmt.Sprintf("%s-%s", id, subject)
+			}
+			opts = append(opts, nats.MsgId(msgId))
+		}
+		ack, err := p.js.PublishMsg(&nats.Msg{Subject: subject, Data: b}, opts...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subject %s: %w", subject, err))
+			continue
+		}
+		if ack.Duplicate {
+			p.logger.Write(slog.LevelDebug, fmt.Sprintf("duplicate publish ignored by stream %s, subject %s", ack.Stream, subject))
+		}
+	}
+
+	if p.js == nil {
+		err := p.conn.FlushTimeout(time.Millisecond * time.Duration(p.cfg.PublishTimeout))
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close drains the connection, delivering anything still buffered, and waits for it to close. If draining takes
+// longer than WaitOnClose the connection is closed regardless and an error is returned.
+func (p *natsPublisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	err := p.conn.Drain()
+	if err != nil {
+		if errors.Is(err, nats.ErrConnectionClosed) {
+			return nil
+		}
+		p.conn.Close()
+		return err
+	}
+
+	select {
+	case <-p.closed:
+		return nil
+	case <-time.After(time.Millisecond * time.Duration(p.cfg.WaitOnClose)):
+		p.conn.Close()
+		return fmt.Errorf("nats connection did not drain within %d ms", p.cfg.WaitOnClose)
+	}
+}
@@ -0,0 +1,160 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package nats
+
+import (
+	"log/slog"
+	"net"
+	"testing"
+	"time"
+
+	natsserver "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// runServer starts an embedded NATS server with JetStream enabled and returns its address.
+func runServer(t *testing.T) config.ServiceInfo {
+	opts := natsserver.DefaultTestOptions
+	opts.Port = -1
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := natsserver.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+	return config.ServiceInfo{Host: "127.0.0.1", Port: s.Addr().(*net.TCPAddr).Port, Protocol: "nats"}
+}
+
+func TestNatsPublisherJetStream(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	provider := runServer(t)
+
+	conn, err := nats.Connect(provider.Uri())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer conn.Close()
+	js, err := conn.JetStream()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	_, err = js.AddStream(&nats.StreamConfig{Name: "ALVARIUM", Subjects: []string{"alvarium.>"}, Duplicates: time.Minute})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	tests := []struct {
+		name        string
+		subjects    []string
+		publishes   int
+		expectError bool
+		expectIds   []string // Nats-Msg-Id of each message stored by the stream, following the annotation ID
+	}{
+		{"acknowledged", []string{"alvarium.annotations"}, 1, false, []string{""}},
+		{"retry deduplicated", []string{"alvarium.retried"}, 2, false, []string{""}},
+		{"distinct id per subject", []string{"alvarium.a", "alvarium.b"}, 1, false, []string{"-alvarium.a", "-alvarium.b"}},
+		{"no stream for subject", []string{"unbound"}, 1, true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := js.PurgeStream("ALVARIUM")
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			cfg := config.NatsConfig{Provider: provider, Subjects: tt.subjects, JetStream: true, PublishTimeout: 500}
+			p, err := NewNatsPublisher(cfg, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if err = p.Connect(); err != nil {
+				t.Fatalf(err.Error())
+			}
+			defer p.Close()
+
+			// A new annotation ID for each test, since the duplicate window outlives the purge
+			msg := testutil.AnnotationMessage(tt.name)
+			list, _ := msg.AnnotationList()
+			for i := 0; i < tt.publishes; i++ {
+				err = p.Publish(msg)
+				test.CheckError(err, tt.expectError, tt.name, t)
+			}
+
+			info, err := js.StreamInfo("ALVARIUM")
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if int(info.State.Msgs) != len(tt.expectIds) {
+				t.Fatalf("expected %d stored messages, found %d", len(tt.expectIds), info.State.Msgs)
+			}
+			for i, id := range tt.expectIds {
+				stored, err := js.GetMsg("ALVARIUM", info.State.FirstSeq+uint64(i))
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+				if stored.Header.Get(nats.MsgIdHdr) != list.Items[0].Id.String()+id {
+					t.Errorf("unexpected %s header %q", nats.MsgIdHdr, stored.Header.Get(nats.MsgIdHdr))
+				}
+			}
+		})
+	}
+}
+
+func TestNatsPublisherClose(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	provider := runServer(t)
+
+	conn, err := nats.Connect(provider.Uri())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer conn.Close()
+	sub, err := conn.SubscribeSync("alvarium")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = conn.Flush(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	p, err := NewNatsPublisher(config.NatsConfig{Provider: provider, Subjects: []string{"alvarium"}}, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Publish(testutil.AnnotationMessage("datakey")); err == nil {
+		t.Errorf("expected an error publishing before Connect")
+	}
+	if err = p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Publish(testutil.AnnotationMessage("datakey")); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Close returns only once the connection has been drained and closed
+	if err = p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if !p.(*natsPublisher).conn.IsClosed() {
+		t.Errorf("expected the connection to be closed")
+	}
+	if _, err = sub.NextMsg(time.Second); err != nil {
+		t.Errorf("expected the published message to be delivered, %v", err)
+	}
+	if err = p.Close(); err != nil {
+		t.Errorf("unexpected error closing twice %v", err)
+	}
+}
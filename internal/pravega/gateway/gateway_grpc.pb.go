@@ -0,0 +1,233 @@
+// Copyright 2024 Dell Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+
+// The subset of the Pravega gRPC gateway (https://github.com/pravega/pravega-grpc-gateway) service used by the
+// SDK. Pravega has no native Go client, so events are written through the gateway.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: gateway.proto
+
+package gateway
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PravegaGateway_CreateScope_FullMethodName  = "/pravega.grpc_gateway.PravegaGateway/CreateScope"
+	PravegaGateway_CreateStream_FullMethodName = "/pravega.grpc_gateway.PravegaGateway/CreateStream"
+	PravegaGateway_WriteEvents_FullMethodName  = "/pravega.grpc_gateway.PravegaGateway/WriteEvents"
+)
+
+// PravegaGatewayClient is the client API for PravegaGateway service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PravegaGatewayClient interface {
+	CreateScope(ctx context.Context, in *CreateScopeRequest, opts ...grpc.CallOption) (*CreateScopeResponse, error)
+	CreateStream(ctx context.Context, in *CreateStreamRequest, opts ...grpc.CallOption) (*CreateStreamResponse, error)
+	WriteEvents(ctx context.Context, opts ...grpc.CallOption) (PravegaGateway_WriteEventsClient, error)
+}
+
+type pravegaGatewayClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPravegaGatewayClient(cc grpc.ClientConnInterface) PravegaGatewayClient {
+	return &pravegaGatewayClient{cc}
+}
+
+func (c *pravegaGatewayClient) CreateScope(ctx context.Context, in *CreateScopeRequest, opts ...grpc.CallOption) (*CreateScopeResponse, error) {
+	out := new(CreateScopeResponse)
+	err := c.cc.Invoke(ctx, PravegaGateway_CreateScope_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pravegaGatewayClient) CreateStream(ctx context.Context, in *CreateStreamRequest, opts ...grpc.CallOption) (*CreateStreamResponse, error) {
+	out := new(CreateStreamResponse)
+	err := c.cc.Invoke(ctx, PravegaGateway_CreateStream_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pravegaGatewayClient) WriteEvents(ctx context.Context, opts ...grpc.CallOption) (PravegaGateway_WriteEventsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PravegaGateway_ServiceDesc.Streams[0], PravegaGateway_WriteEvents_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pravegaGatewayWriteEventsClient{stream}
+	return x, nil
+}
+
+type PravegaGateway_WriteEventsClient interface {
+	Send(*WriteEventsRequest) error
+	CloseAndRecv() (*WriteEventsResponse, error)
+	grpc.ClientStream
+}
+
+type pravegaGatewayWriteEventsClient struct {
+	grpc.ClientStream
+}
+
+func (x *pravegaGatewayWriteEventsClient) Send(m *WriteEventsRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *pravegaGatewayWriteEventsClient) CloseAndRecv() (*WriteEventsResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(WriteEventsResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PravegaGatewayServer is the server API for PravegaGateway service.
+// All implementations must embed UnimplementedPravegaGatewayServer
+// for forward compatibility
+type PravegaGatewayServer interface {
+	CreateScope(context.Context, *CreateScopeRequest) (*CreateScopeResponse, error)
+	CreateStream(context.Context, *CreateStreamRequest) (*CreateStreamResponse, error)
+	WriteEvents(PravegaGateway_WriteEventsServer) error
+	mustEmbedUnimplementedPravegaGatewayServer()
+}
+
+// UnimplementedPravegaGatewayServer must be embedded to have forward compatible implementations.
+type UnimplementedPravegaGatewayServer struct {
+}
+
+func (UnimplementedPravegaGatewayServer) CreateScope(context.Context, *CreateScopeRequest) (*CreateScopeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateScope not implemented")
+}
+func (UnimplementedPravegaGatewayServer) CreateStream(context.Context, *CreateStreamRequest) (*CreateStreamResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateStream not implemented")
+}
+func (UnimplementedPravegaGatewayServer) WriteEvents(PravegaGateway_WriteEventsServer) error {
+	return status.Errorf(codes.Unimplemented, "method WriteEvents not implemented")
+}
+func (UnimplementedPravegaGatewayServer) mustEmbedUnimplementedPravegaGatewayServer() {}
+
+// UnsafePravegaGatewayServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PravegaGatewayServer will
+// result in compilation errors.
+type UnsafePravegaGatewayServer interface {
+	mustEmbedUnimplementedPravegaGatewayServer()
+}
+
+func RegisterPravegaGatewayServer(s grpc.ServiceRegistrar, srv PravegaGatewayServer) {
+	s.RegisterService(&PravegaGateway_ServiceDesc, srv)
+}
+
+func _PravegaGateway_CreateScope_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateScopeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PravegaGatewayServer).CreateScope(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PravegaGateway_CreateScope_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PravegaGatewayServer).CreateScope(ctx, req.(*CreateScopeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PravegaGateway_CreateStream_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateStreamRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PravegaGatewayServer).CreateStream(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PravegaGateway_CreateStream_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PravegaGatewayServer).CreateStream(ctx, req.(*CreateStreamRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PravegaGateway_WriteEvents_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(PravegaGatewayServer).WriteEvents(&pravegaGatewayWriteEventsServer{stream})
+}
+
+type PravegaGateway_WriteEventsServer interface {
+	SendAndClose(*WriteEventsResponse) error
+	Recv() (*WriteEventsRequest, error)
+	grpc.ServerStream
+}
+
+type pravegaGatewayWriteEventsServer struct {
+	grpc.ServerStream
+}
+
+func (x *pravegaGatewayWriteEventsServer) SendAndClose(m *WriteEventsResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *pravegaGatewayWriteEventsServer) Recv() (*WriteEventsRequest, error) {
+	m := new(WriteEventsRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PravegaGateway_ServiceDesc is the grpc.ServiceDesc for PravegaGateway service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PravegaGateway_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pravega.grpc_gateway.PravegaGateway",
+	HandlerType: (*PravegaGatewayServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateScope",
+			Handler:    _PravegaGateway_CreateScope_Handler,
+		},
+		{
+			MethodName: "CreateStream",
+			Handler:    _PravegaGateway_CreateStream_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WriteEvents",
+			Handler:       _PravegaGateway_WriteEvents_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "gateway.proto",
+}
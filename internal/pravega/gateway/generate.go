@@ -1,5 +1,5 @@
 /*******************************************************************************
- * Copyright 2023 Dell Inc.
+ * Copyright 2024 Dell Inc.
  *
  * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
  * in compliance with the License. You may obtain a copy of the License at
@@ -12,34 +12,7 @@
  * the License.
  *******************************************************************************/
 
-package mock
+// Package gateway contains the generated client for the Pravega gRPC gateway.
+package gateway
 
-import (
-	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
-	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
-	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
-)
-
-type mockPublisher struct {
-	cfg    config.MockStreamConfig
-	logger interfaces.Logger
-}
-
-func NewMockPublisher(cfg config.MockStreamConfig, logger interfaces.Logger) interfaces.StreamProvider {
-	return &mockPublisher{
-		cfg:    cfg,
-		logger: logger,
-	}
-}
-
-func (p *mockPublisher) Connect() error {
-	return nil
-}
-
-func (p *mockPublisher) Publish(msg message.PublishWrapper) error {
-	return nil
-}
-
-func (p *mockPublisher) Close() error {
-	return nil
-}
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative gateway.proto
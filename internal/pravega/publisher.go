@@ -0,0 +1,164 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pravega
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/grpcconn"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/pravega/gateway"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	defaultConnectTimeout int = 5000
+	defaultWriteTimeout   int = 5000
+)
+
+type pravegaPublisher struct {
+	cfg         config.PravegaConfig
+	logger      interfaces.Logger
+	dialOptions []grpc.DialOption
+	conn        *grpc.ClientConn
+	client      gateway.PravegaGatewayClient
+}
+
+// NewPravegaPublisher validates the configuration and prepares a publisher. No connection to the gateway is made
+// until Connect is called.
+func NewPravegaPublisher(cfg config.PravegaConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if cfg.Scope == "" || cfg.Stream == "" {
+		return nil, errors.New("pravega scope and stream must be provided")
+	}
+	if cfg.MinSegments == 0 {
+		cfg.MinSegments = 1
+	}
+	if cfg.ConnectTimeout == 0 {
+		cfg.ConnectTimeout = defaultConnectTimeout
+	}
+	if cfg.WriteTimeout == 0 {
+		cfg.WriteTimeout = defaultWriteTimeout
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	creds := insecure.NewCredentials()
+	if tlsCfg != nil {
+		creds = credentials.NewTLS(tlsCfg)
+	}
+
+	p := pravegaPublisher{
+		cfg:         cfg,
+		logger:      logger,
+		dialOptions: []grpc.DialOption{grpc.WithTransportCredentials(creds)},
+	}
+	return &p, nil
+}
+
+// Connect establishes the gateway connection and, if configured, creates the scope and stream. Once connected,
+// the underlying gRPC connection transparently re-establishes itself should the gateway become unavailable.
+func (p *pravegaPublisher) Connect() error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.ConnectTimeout))
+	defer cancel()
+
+	conn, err := grpcconn.Connect(ctx, p.cfg.Provider.Address(), p.dialOptions...)
+	if err != nil {
+		return fmt.Errorf("failed to connect to pravega gateway %s: %w", p.cfg.Provider.Address(), err)
+	}
+	p.conn = conn
+	p.client = gateway.NewPravegaGatewayClient(conn)
+
+	if p.cfg.CreateStream {
+		return p.createStream(ctx)
+	}
+	return nil
+}
+
+// Publish writes the message as a single event and waits for the gateway to acknowledge it. The routing key is the
+// hash of the annotated data so that all annotations for one datum are ordered within the same segment.
+func (p *pravegaPublisher) Publish(msg message.PublishWrapper) error {
+	if p.client == nil {
+		return errors.New("pravega publisher is not connected")
+	}
+
+	b, _ := json.Marshal(msg)
+	p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, stream %s/%s %s", p.cfg.Scope, p.cfg.Stream, string(b)))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.WriteTimeout))
+	defer cancel()
+
+	writer, err := p.client.WriteEvents(ctx)
+	if err != nil {
+		return err
+	}
+	err = writer.Send(&gateway.WriteEventsRequest{
+		Event:      b,
+		Scope:      p.cfg.Scope,
+		Stream:     p.cfg.Stream,
+		RoutingKey: msg.DataKey(),
+	})
+	// io.EOF indicates the gateway aborted the stream, the actual cause is returned by CloseAndRecv
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	_, err = writer.CloseAndRecv()
+	return err
+}
+
+func (p *pravegaPublisher) Close() error {
+	if p.conn == nil {
+		return nil
+	}
+	return p.conn.Close()
+}
+
+func (p *pravegaPublisher) createStream(ctx context.Context) error {
+	scope, err := p.client.CreateScope(ctx, &gateway.CreateScopeRequest{Scope: p.cfg.Scope})
+	if err != nil {
+		return fmt.Errorf("failed to create pravega scope %s: %w", p.cfg.Scope, err)
+	}
+	if scope.Created {
+		p.logger.Write(slog.LevelInfo, fmt.Sprintf("created pravega scope %s", p.cfg.Scope))
+	}
+
+	stream, err := p.client.CreateStream(ctx, &gateway.CreateStreamRequest{
+		Scope:  p.cfg.Scope,
+		Stream: p.cfg.Stream,
+		ScalingPolicy: &gateway.ScalingPolicy{
+			ScaleType:      gateway.ScalingPolicy_FIXED_NUM_SEGMENTS,
+			MinNumSegments: int32(p.cfg.MinSegments),
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create pravega stream %s/%s: %w", p.cfg.Scope, p.cfg.Stream, err)
+	}
+	if stream.Created {
+		p.logger.Write(slog.LevelInfo, fmt.Sprintf("created pravega stream %s/%s", p.cfg.Scope, p.cfg.Stream))
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pravega
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/pravega/gateway"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// fakeGateway stands in for the Pravega gRPC gateway, recording created streams and written events.
+type fakeGateway struct {
+	gateway.UnimplementedPravegaGatewayServer
+	mutex   sync.Mutex
+	streams map[string]bool
+	events  []*gateway.WriteEventsRequest
+}
+
+func (g *fakeGateway) CreateScope(ctx context.Context, req *gateway.CreateScopeRequest) (*gateway.CreateScopeResponse, error) {
+	return &gateway.CreateScopeResponse{Created: true}, nil
+}
+
+func (g *fakeGateway) CreateStream(ctx context.Context, req *gateway.CreateStreamRequest) (*gateway.CreateStreamResponse, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+	g.streams[req.Scope+"/"+req.Stream] = true
+	return &gateway.CreateStreamResponse{Created: true}, nil
+}
+
+func (g *fakeGateway) WriteEvents(stream gateway.PravegaGateway_WriteEventsServer) error {
+	for {
+		req, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(&gateway.WriteEventsResponse{})
+		}
+		if err != nil {
+			return err
+		}
+
+		g.mutex.Lock()
+		exists := g.streams[req.Scope+"/"+req.Stream]
+		if exists {
+			g.events = append(g.events, req)
+		}
+		g.mutex.Unlock()
+		if !exists {
+			return status.Errorf(codes.NotFound, "stream %s/%s does not exist", req.Scope, req.Stream)
+		}
+	}
+}
+
+func newTestPublisher(t *testing.T, cfg config.PravegaConfig, srv *fakeGateway) *pravegaPublisher {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	gateway.RegisterPravegaGatewayServer(server, srv)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	p, err := NewPravegaPublisher(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	publisher := p.(*pravegaPublisher)
+	publisher.dialOptions = append(publisher.dialOptions, grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+		return listener.DialContext(ctx)
+	}))
+	return publisher
+}
+
+func TestPravegaPublisher(t *testing.T) {
+	msg := testutil.AnnotationMessage("datakey")
+
+	tests := []struct {
+		name         string
+		createStream bool
+		expectError  bool
+	}{
+		{"stream created on connect", true, false},
+		{"missing stream", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := &fakeGateway{streams: map[string]bool{}}
+			cfg := config.PravegaConfig{Scope: "alvarium", Stream: "annotations", CreateStream: tt.createStream}
+			p := newTestPublisher(t, cfg, srv)
+			if err := p.Connect(); err != nil {
+				t.Fatalf(err.Error())
+			}
+			defer p.Close()
+
+			err := p.Publish(msg)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("unexpected publish result: %v", err)
+			}
+			if err != nil {
+				if status.Code(err) != codes.NotFound {
+					t.Errorf("expected gateway status to be returned, received %v", err)
+				}
+				return
+			}
+
+			if len(srv.events) != 1 {
+				t.Fatalf("expected 1 event, received %d", len(srv.events))
+			}
+			if srv.events[0].RoutingKey != "datakey" {
+				t.Errorf("unexpected routing key %s", srv.events[0].RoutingKey)
+			}
+			var received message.PublishWrapper
+			if err := json.Unmarshal(srv.events[0].Event, &received); err != nil || received.Action != msg.Action {
+				t.Errorf("unexpected event content %s", string(srv.events[0].Event))
+			}
+		})
+	}
+}
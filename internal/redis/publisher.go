@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultPublishTimeout int = 2000
+
+	// Each stream entry holds the fields of the PublishWrapper
+	fieldAction      = "action"
+	fieldMessageType = "messageType"
+	fieldContent     = "content"
+)
+
+type redisPublisher struct {
+	cfg     config.RedisConfig
+	logger  interfaces.Logger
+	options *redis.Options
+	client  *redis.Client
+}
+
+// NewRedisPublisher validates the configuration and prepares a publisher. No connection to the server is made
+// until Connect is called.
+func NewRedisPublisher(cfg config.RedisConfig, logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if len(cfg.Streams) == 0 {
+		return nil, errors.New("at least one Redis stream must be provided")
+	}
+	if cfg.PublishTimeout == 0 {
+		cfg.PublishTimeout = defaultPublishTimeout
+	}
+
+	options, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := redisPublisher{
+		cfg:     cfg,
+		logger:  logger,
+		options: options,
+	}
+	return &p, nil
+}
+
+// Connect creates the client and checks that the server is reachable and accepts the credentials.
+func (p *redisPublisher) Connect() error {
+	client, err := connect(p.options, p.cfg)
+	if err != nil {
+		return err
+	}
+	p.client = client
+	return nil
+}
+
+// Publish adds the message to every configured stream as an entry with the action, messageType and content fields.
+// Streams are trimmed to about MaxLen entries as they grow.
+func (p *redisPublisher) Publish(msg message.PublishWrapper) error {
+	if p.client == nil {
+		return errors.New("redis publisher is not connected")
+	}
+
+	var errs []error
+	for _, stream := range p.cfg.Streams {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, stream %s %s %s", stream, msg.Action, string(msg.Content)))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.PublishTimeout))
+		err := p.client.XAdd(ctx, &redis.XAddArgs{
+			Stream: stream,
+			MaxLen: p.cfg.MaxLen,
+			Approx: p.cfg.MaxLen > 0, // Trimming to whole nodes is far cheaper than an exact length
+			Values: []interface{}{
+				fieldAction, string(msg.Action),
+				fieldMessageType, msg.MessageType,
+				fieldContent, msg.Content,
+			},
+		}).Err()
+		cancel()
+		if err != nil {
+			errs = append(errs, fmt.Errorf("stream %s: %w", stream, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *redisPublisher) Close() error {
+	if p.client == nil {
+		return nil
+	}
+	return p.client.Close()
+}
+
+// clientOptions translates the configuration shared by the publisher and subscriber
+func clientOptions(cfg config.RedisConfig) (*redis.Options, error) {
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	return &redis.Options{
+		Addr:      cfg.Provider.Address(),
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		DB:        cfg.Db,
+		TLSConfig: tlsCfg,
+	}, nil
+}
+
+func connect(options *redis.Options, cfg config.RedisConfig) (*redis.Client, error) {
+	client := redis.NewClient(options)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(cfg.PublishTimeout))
+	defer cancel()
+	err := client.Ping(ctx).Err()
+	if err != nil {
+		_ = client.Close()
+		return nil, fmt.Errorf("failed to connect to redis at %s: %w", options.Addr, err)
+	}
+	return client, nil
+}
@@ -0,0 +1,112 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+	"github.com/redis/go-redis/v9"
+)
+
+// serverConfig points the configuration at an in-memory server
+func serverConfig(t *testing.T, server *miniredis.Miniredis, cfg config.RedisConfig) config.RedisConfig {
+	port, err := strconv.Atoi(server.Port())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	cfg.Provider = config.ServiceInfo{Host: server.Host(), Port: port}
+	return cfg
+}
+
+func TestRedisPublisher(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           config.RedisConfig
+		password      string // required by the server
+		publishes     int
+		expectLength  int64
+		expectConnect bool // whether Connect is expected to fail
+	}{
+		{"publish to streams", config.RedisConfig{Streams: []string{"alvarium", "audit"}}, "", 3, 3, false},
+		{"trim", config.RedisConfig{Streams: []string{"alvarium"}, MaxLen: 2}, "", 5, 2, false},
+		{"password", config.RedisConfig{Streams: []string{"alvarium"}, Password: "secret"}, "secret", 1, 1, false},
+		{"wrong password", config.RedisConfig{Streams: []string{"alvarium"}, Password: "guess"}, "secret", 0, 0, true},
+		{"user", config.RedisConfig{Streams: []string{"alvarium"}, Username: "sdk", Password: "secret"}, "", 1, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := miniredis.RunT(t)
+			if tt.password != "" {
+				server.RequireAuth(tt.password)
+			}
+			if tt.cfg.Username != "" {
+				server.RequireUserAuth(tt.cfg.Username, tt.cfg.Password)
+			}
+
+			logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+			p, err := NewRedisPublisher(serverConfig(t, server, tt.cfg), logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			err = p.Connect()
+			test.CheckError(err, tt.expectConnect, tt.name, t)
+			if err != nil {
+				return
+			}
+			defer p.Close()
+
+			for i := 0; i < tt.publishes; i++ {
+				err = p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+
+			client := redis.NewClient(&redis.Options{Addr: server.Addr(), Username: tt.cfg.Username, Password: tt.cfg.Password})
+			defer client.Close()
+			for _, stream := range tt.cfg.Streams {
+				entries, err := client.XRange(context.Background(), stream, "-", "+").Result()
+				if err != nil {
+					t.Fatalf(err.Error())
+				}
+				if int64(len(entries)) != tt.expectLength {
+					t.Fatalf("unexpected length %d of stream %s", len(entries), stream)
+				}
+				last := entries[len(entries)-1].Values
+				expected := testutil.AnnotationMessage(fmt.Sprintf("key%d", tt.publishes-1))
+				if last[fieldAction] != string(message.ActionCreate) || last[fieldMessageType] != expected.MessageType {
+					t.Errorf("unexpected entry %v", last)
+				}
+				var list contracts.AnnotationList
+				err = json.Unmarshal([]byte(last[fieldContent].(string)), &list)
+				if err != nil || list.Items[0].Key != fmt.Sprintf("key%d", tt.publishes-1) {
+					t.Errorf("unexpected content %v", last[fieldContent])
+				}
+			}
+		})
+	}
+}
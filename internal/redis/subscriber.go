@@ -0,0 +1,345 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultGroup     = "alvarium"
+	defaultClaimIdle = 30000
+
+	readCount    = 100
+	readBlock    = time.Second // Bounds how long Close waits for a read in progress
+	retryBackoff = time.Second
+)
+
+type redisSubscriber struct {
+	cfg     config.RedisConfig
+	logger  interfaces.Logger
+	options *redis.Options
+	client  *redis.Client
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewRedisSubscriber prepares a subscriber reading the configured streams as a member of a consumer group, so that
+// several subscribers sharing a group divide the messages between them.
+func NewRedisSubscriber(cfg config.RedisConfig, logger interfaces.Logger) (interfaces.StreamSubscriber, error) {
+	if len(cfg.Streams) == 0 {
+		return nil, errors.New("at least one Redis stream must be provided")
+	}
+	if cfg.PublishTimeout == 0 {
+		cfg.PublishTimeout = defaultPublishTimeout
+	}
+	if cfg.Group == "" {
+		cfg.Group = defaultGroup
+	}
+	if cfg.Consumer == "" {
+		host, err := os.Hostname()
+		if err != nil {
+			return nil, err
+		}
+		cfg.Consumer = host
+	}
+	if cfg.ClaimIdle == 0 {
+		cfg.ClaimIdle = defaultClaimIdle
+	}
+
+	options, err := clientOptions(cfg)
+	if err != nil {
+		return nil, err
+	}
+	p := redisSubscriber{
+		cfg:     cfg,
+		logger:  logger,
+		options: options,
+		closed:  make(chan struct{}),
+	}
+	return &p, nil
+}
+
+// Connect creates the client and the consumer group on each stream if it does not exist yet. A new group starts at
+// the beginning of the stream, so entries still retained are delivered.
+func (p *redisSubscriber) Connect() error {
+	client, err := connect(p.options, p.cfg)
+	if err != nil {
+		return err
+	}
+
+	for _, stream := range p.cfg.Streams {
+		err = p.createGroup(client, stream)
+		if err != nil {
+			_ = client.Close()
+			return err
+		}
+	}
+	p.client = client
+	return nil
+}
+
+// Subscribe delivers new messages from all configured streams. A message is acknowledged once handler accepts it.
+// Rejected messages stay pending and are delivered again once they have been idle for ClaimIdle, as are messages
+// left pending by a consumer of the group that stopped. Messages that cannot be decoded are reported and acknowledged
+// since they would never succeed.
+func (p *redisSubscriber) Subscribe(ctx context.Context, handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) error {
+	if p.client == nil {
+		return errors.New("redis subscriber is not connected")
+	}
+	if onError == nil {
+		onError = func(err error) {
+			p.logger.Error(err.Error())
+		}
+	}
+
+	// Messages this consumer received before a restart but never acknowledged come first
+	for _, stream := range p.cfg.Streams {
+		err := p.pending(ctx, stream, handler, onError)
+		if err != nil && !p.done(ctx) {
+			return err
+		}
+	}
+
+	streams := make([]string, 0, 2*len(p.cfg.Streams))
+	streams = append(streams, p.cfg.Streams...)
+	for range p.cfg.Streams {
+		streams = append(streams, ">")
+	}
+
+	claimIdle := time.Millisecond * time.Duration(p.cfg.ClaimIdle)
+	lastClaim := time.Now()
+	for !p.done(ctx) {
+		if time.Since(lastClaim) >= claimIdle {
+			p.claim(ctx, claimIdle, handler, onError)
+			lastClaim = time.Now()
+		}
+
+		err := p.read(ctx, streams, readBlock, handler, onError)
+		if err == nil || p.done(ctx) {
+			continue
+		}
+		if isNoGroup(err) {
+			// The stream or group was deleted while reading, e.g. by an administrator
+			for _, stream := range p.cfg.Streams {
+				if createErr := p.createGroup(p.client, stream); createErr != nil {
+					err = createErr
+				}
+			}
+		}
+		p.logger.Error(fmt.Sprintf("failed to read from redis, retrying: %s", err.Error()))
+		select {
+		case <-ctx.Done():
+		case <-p.closed:
+		case <-time.After(retryBackoff):
+		}
+	}
+	return nil
+}
+
+func (p *redisSubscriber) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closed)
+	})
+	if p.client == nil {
+		return nil
+	}
+	return p.client.Close()
+}
+
+// read waits up to block for new entries of the given streams and delivers them
+func (p *redisSubscriber) read(ctx context.Context, streams []string, block time.Duration,
+	handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) error {
+	result, err := p.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    p.cfg.Group,
+		Consumer: p.cfg.Consumer,
+		Streams:  streams,
+		Count:    readCount,
+		Block:    block,
+	}).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, s := range result {
+		for _, m := range s.Messages {
+			p.deliver(ctx, s.Stream, m, handler, onError)
+		}
+	}
+	return nil
+}
+
+// pending delivers the entries of the stream already delivered to this consumer but not acknowledged
+func (p *redisSubscriber) pending(ctx context.Context, stream string, handler interfaces.DeliveryHandler,
+	onError interfaces.ErrorHandler) error {
+	after := "0"
+	for !p.done(ctx) {
+		result, err := p.client.XReadGroup(ctx, &redis.XReadGroupArgs{
+			Group:    p.cfg.Group,
+			Consumer: p.cfg.Consumer,
+			Streams:  []string{stream, after},
+			Count:    readCount,
+			Block:    -1,
+		}).Result()
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if len(result) == 0 || len(result[0].Messages) == 0 {
+			return nil
+		}
+
+		for _, m := range result[0].Messages {
+			p.deliver(ctx, stream, m, handler, onError)
+			after = m.ID
+		}
+	}
+	return nil
+}
+
+// claim takes over entries of the group that have been pending for at least idle, whichever consumer they were
+// delivered to, and delivers them again.
+func (p *redisSubscriber) claim(ctx context.Context, idle time.Duration, handler interfaces.DeliveryHandler,
+	onError interfaces.ErrorHandler) {
+	for _, stream := range p.cfg.Streams {
+		start := "0-0"
+		for {
+			messages, next, err := p.client.XAutoClaim(ctx, &redis.XAutoClaimArgs{
+				Stream:   stream,
+				Group:    p.cfg.Group,
+				Consumer: p.cfg.Consumer,
+				MinIdle:  idle,
+				Start:    start,
+				Count:    readCount,
+			}).Result()
+			if err != nil {
+				if !p.done(ctx) {
+					p.logger.Error(fmt.Sprintf("failed to claim pending messages of stream %s: %s", stream, err.Error()))
+				}
+				break
+			}
+			for _, m := range messages {
+				p.deliver(ctx, stream, m, handler, onError)
+			}
+			if next == "0-0" || p.done(ctx) {
+				break
+			}
+			start = next
+		}
+	}
+}
+
+func (p *redisSubscriber) deliver(ctx context.Context, stream string, m redis.XMessage,
+	handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler) {
+	// Entries of messages deleted while pending have no fields left
+	if len(m.Values) == 0 {
+		p.ack(ctx, stream, m.ID)
+		return
+	}
+
+	w := message.PublishWrapper{
+		Action:      message.SdkAction(value(m.Values, fieldAction)),
+		MessageType: value(m.Values, fieldMessageType),
+		Content:     []byte(value(m.Values, fieldContent)),
+	}
+	payload, _ := json.Marshal(w)
+	source := fmt.Sprintf("%s/%s", stream, m.ID)
+
+	deliveries, err := message.NewDeliveries(payload, stream)
+	if err != nil {
+		onError(&message.DeliveryError{Source: source, Payload: payload, Err: err})
+		p.ack(ctx, stream, m.ID)
+		return
+	}
+
+	// The entry is only acknowledged once every message of a batch is accepted, so a rejection leads to the whole
+	// batch being delivered again
+	for _, d := range deliveries {
+		d.Timestamp = entryTime(m.ID)
+		err = handler(ctx, d)
+		if err != nil {
+			onError(&message.DeliveryError{Source: source, Payload: payload, Err: err})
+			return
+		}
+	}
+	p.ack(ctx, stream, m.ID)
+}
+
+func (p *redisSubscriber) ack(ctx context.Context, stream string, id string) {
+	err := p.client.XAck(ctx, stream, p.cfg.Group, id).Err()
+	if err != nil && !p.done(ctx) {
+		p.logger.Error(fmt.Sprintf("failed to acknowledge %s on stream %s, it will be delivered again: %s",
+			id, stream, err.Error()))
+	}
+}
+
+func (p *redisSubscriber) createGroup(client *redis.Client, stream string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*time.Duration(p.cfg.PublishTimeout))
+	defer cancel()
+	err := client.XGroupCreateMkStream(ctx, stream, p.cfg.Group, "0").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create group %s on stream %s: %w", p.cfg.Group, stream, err)
+	}
+	return nil
+}
+
+func (p *redisSubscriber) done(ctx context.Context) bool {
+	select {
+	case <-ctx.Done():
+		return true
+	case <-p.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNoGroup(err error) bool {
+	return strings.HasPrefix(err.Error(), "NOGROUP")
+}
+
+func value(values map[string]interface{}, field string) string {
+	s, _ := values[field].(string)
+	return s
+}
+
+// entryTime returns the time at which the server added the entry, which is the first part of its ID
+func entryTime(id string) time.Time {
+	ms, _, _ := strings.Cut(id, "-")
+	n, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return time.Time{}
+	}
+	return time.UnixMilli(n)
+}
@@ -0,0 +1,130 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/redis/go-redis/v9"
+)
+
+func startSubscriber(t *testing.T, cfg config.RedisConfig, c *testutil.Collector) (interfaces.StreamSubscriber, chan error) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	s, err := NewRedisSubscriber(cfg, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	return s, testutil.StartSubscriber(t, s, c)
+}
+
+func TestRedisSubscriber(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	server := miniredis.RunT(t)
+	cfg := serverConfig(t, server, config.RedisConfig{
+		Streams:   []string{"alvarium", "audit"},
+		Consumer:  "one",
+		ClaimIdle: 100,
+	})
+
+	// The first delivery is rejected and must be claimed again once idle
+	c := testutil.NewCollector(1)
+	s, done := startSubscriber(t, cfg, c)
+
+	p, err := NewRedisPublisher(config.RedisConfig{Provider: cfg.Provider, Streams: []string{"alvarium"}}, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	err = p.Connect()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer p.Close()
+	for i := 0; i < 3; i++ {
+		_ = p.Publish(testutil.AnnotationMessage(fmt.Sprintf("key%d", i)))
+	}
+	// Messages of a batch are delivered one by one
+	envelope, _ := message.NewBatch([]message.PublishWrapper{testutil.AnnotationMessage("key3"), testutil.AnnotationMessage("key4")})
+	_ = p.Publish(envelope)
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	defer client.Close()
+	err = client.XAdd(context.Background(), &redis.XAddArgs{
+		Stream: "audit",
+		Values: []string{fieldAction, "bogus", fieldMessageType, "string", fieldContent, "{}"},
+	}).Err()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	c.Await(t, 5)
+	c.AwaitErrors(t, 2)
+	_ = s.Close()
+	if err = <-done; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	keys := make(map[string]bool)
+	for _, d := range c.Received() {
+		if d.Action != message.ActionCreate || len(d.Annotations.Items) != 1 || d.Source != "alvarium" ||
+			d.Timestamp.IsZero() {
+			t.Errorf("unexpected delivery %v", d)
+			continue
+		}
+		keys[d.Annotations.Items[0].Key] = true
+	}
+	if len(keys) != 5 {
+		t.Errorf("expected 5 distinct messages, got %v", keys)
+	}
+	if len(c.Errors()) != 2 {
+		t.Errorf("expected a rejection and a decoding error, got %v", c.Errors())
+	}
+
+	for _, stream := range cfg.Streams {
+		pending, err := client.XPending(context.Background(), stream, defaultGroup).Result()
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+		if pending.Count != 0 {
+			t.Errorf("expected no pending messages on %s, got %d", stream, pending.Count)
+		}
+	}
+
+	// A message rejected before a restart is delivered first by the same consumer
+	cfg.ClaimIdle = 60000
+	c = testutil.NewCollector(1)
+	s, done = startSubscriber(t, cfg, c)
+	_ = p.Publish(testutil.AnnotationMessage("resumed"))
+	c.AwaitErrors(t, 1)
+	_ = s.Close()
+	<-done
+
+	c = testutil.NewCollector(0)
+	s, done = startSubscriber(t, cfg, c)
+	c.Await(t, 1)
+	_ = s.Close()
+	<-done
+	if received := c.Received(); len(received) != 1 || received[0].Annotations.Items[0].Key != "resumed" {
+		t.Errorf("unexpected deliveries after resume %v", received)
+	}
+}
@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package testutil contains the fixtures and helpers shared by the stream tests.
+package testutil
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// awaitTimeout bounds how long the Collector waits for deliveries and errors.
+const awaitTimeout = 5 * time.Second
+
+// AnnotationMessage returns a PublishWrapper carrying an AnnotationList with a satisfied TPM annotation for each key.
+func AnnotationMessage(keys ...string) message.PublishWrapper {
+	list := contracts.AnnotationList{Items: []contracts.Annotation{}}
+	for _, key := range keys {
+		list.Items = append(list.Items, contracts.NewAnnotation(key, contracts.SHA256Hash, "host", contracts.Application,
+			contracts.AnnotationTPM, true))
+	}
+	b, _ := json.Marshal(list)
+	return message.PublishWrapper{Action: message.ActionCreate, MessageType: message.AnnotationListType, Content: b}
+}
+
+// Keys returns n distinct annotation keys, key0 through key<n-1>.
+func Keys(n int) []string {
+	keys := make([]string, n)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key%d", i)
+	}
+	return keys
+}
+
+// Collector records the deliveries and errors reported to a subscriber, rejecting the first failures deliveries.
+type Collector struct {
+	mutex     sync.Mutex
+	failures  int
+	received  []message.Delivery
+	errs      []error
+	delivered chan struct{}
+}
+
+func NewCollector(failures int) *Collector {
+	return &Collector{failures: failures, delivered: make(chan struct{}, 64)}
+}
+
+// Handle is the subscriber's message handler.
+func (c *Collector) Handle(_ context.Context, d message.Delivery) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.failures > 0 {
+		c.failures--
+		return errors.New("not ready")
+	}
+	c.received = append(c.received, d)
+	c.delivered <- struct{}{}
+	return nil
+}
+
+// OnError is the subscriber's error handler.
+func (c *Collector) OnError(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.errs = append(c.errs, err)
+}
+
+// Received returns the deliveries accepted so far.
+func (c *Collector) Received() []message.Delivery {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]message.Delivery(nil), c.received...)
+}
+
+// Errors returns the errors reported so far.
+func (c *Collector) Errors() []error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]error(nil), c.errs...)
+}
+
+// Await fails the test unless n more deliveries are accepted in time.
+func (c *Collector) Await(t *testing.T, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		select {
+		case <-c.delivered:
+		case <-time.After(awaitTimeout):
+			t.Fatalf("timed out waiting for delivery %d", i+1)
+		}
+	}
+}
+
+// AwaitErrors fails the test unless n errors in total have been reported in time.
+func (c *Collector) AwaitErrors(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(awaitTimeout)
+	for time.Now().Before(deadline) {
+		if len(c.Errors()) >= n {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d errors", n)
+}
+
+// StartSubscriber connects s and subscribes c to it in the background. The result of Subscribe is sent on the
+// returned channel.
+func StartSubscriber(t *testing.T, s interfaces.StreamSubscriber, c *Collector) chan error {
+	t.Helper()
+	if err := s.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	done := make(chan error, 1)
+	go func() {
+		done <- s.Subscribe(context.Background(), c.Handle, c.OnError)
+	}()
+	return done
+}
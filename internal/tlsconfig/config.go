@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+)
+
+// New builds a *tls.Config from the supplied settings. It returns nil when TLS is not enabled. Server certificates
+// are verified against the system roots, or only against the CAs in CaPath when it is set.
+func New(info config.TlsInfo) (*tls.Config, error) {
+	if !info.Enabled {
+		return nil, nil
+	}
+
+	minVersion, err := parseVersion(info.MinVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &tls.Config{
+		MinVersion:         minVersion,
+		ServerName:         info.ServerName,
+		InsecureSkipVerify: info.InsecureSkipVerify,
+	}
+
+	if info.CaPath != "" {
+		pool := x509.NewCertPool()
+		b, err := os.ReadFile(info.CaPath)
+		if err != nil {
+			return nil, err
+		}
+		if !pool.AppendCertsFromPEM(b) {
+			return nil, fmt.Errorf("no certificates found in CA bundle %s", info.CaPath)
+		}
+		cfg.RootCAs = pool
+	}
+
+	if info.CertPath != "" || info.KeyPath != "" {
+		if info.CertPath == "" || info.KeyPath == "" {
+			return nil, errors.New("both certPath and keyPath are required for client certificate authentication")
+		}
+		cert, err := tls.LoadX509KeyPair(info.CertPath, info.KeyPath)
+		if err != nil {
+			return nil, err
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+func parseVersion(v string) (uint16, error) {
+	switch v {
+	case "":
+		return tls.VersionTLS12, nil
+	case "1.0":
+		return tls.VersionTLS10, nil
+	case "1.1":
+		return tls.VersionTLS11, nil
+	case "1.2":
+		return tls.VersionTLS12, nil
+	case "1.3":
+		return tls.VersionTLS13, nil
+	default:
+		return 0, fmt.Errorf("unsupported TLS minimum version %s", v)
+	}
+}
@@ -0,0 +1,114 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package tlsconfig
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// writeSelfSigned generates a self-signed certificate and key, returning the paths of the PEM files.
+func writeSelfSigned(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	_ = os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	_ = os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600)
+	return certPath, keyPath
+}
+
+func TestNew(t *testing.T) {
+	certPath, keyPath := writeSelfSigned(t)
+
+	tests := []struct {
+		name        string
+		info        config.TlsInfo
+		expectNil   bool
+		expectError bool
+	}{
+		{"disabled", config.TlsInfo{CaPath: "missing"}, true, false},
+		{"defaults", config.TlsInfo{Enabled: true}, false, false},
+		{"mutual tls", config.TlsInfo{Enabled: true, CaPath: certPath, CertPath: certPath, KeyPath: keyPath, ServerName: "broker", MinVersion: "1.3"}, false, false},
+		{"missing ca", config.TlsInfo{Enabled: true, CaPath: "missing"}, true, true},
+		{"invalid ca", config.TlsInfo{Enabled: true, CaPath: keyPath}, true, true},
+		{"cert without key", config.TlsInfo{Enabled: true, CertPath: certPath}, true, true},
+		{"invalid version", config.TlsInfo{Enabled: true, MinVersion: "2.0"}, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg, err := New(tt.info)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if (cfg == nil) != tt.expectNil {
+				t.Fatalf("unexpected config result %v", cfg)
+			}
+			if cfg == nil {
+				return
+			}
+			if cfg.ServerName != tt.info.ServerName {
+				t.Errorf("unexpected server name %s", cfg.ServerName)
+			}
+			if tt.info.MinVersion == "1.3" && cfg.MinVersion != tls.VersionTLS13 {
+				t.Errorf("unexpected minimum version %d", cfg.MinVersion)
+			}
+			if tt.info.CertPath != "" && len(cfg.Certificates) != 1 {
+				t.Errorf("expected client certificate to be loaded")
+			}
+			if tt.info.CaPath != "" {
+				// The configured bundle replaces the system roots rather than adding to them
+				b, _ := os.ReadFile(tt.info.CaPath)
+				expected := x509.NewCertPool()
+				expected.AppendCertsFromPEM(b)
+				if !cfg.RootCAs.Equal(expected) {
+					t.Errorf("expected only the configured CAs to be trusted")
+				}
+			} else if cfg.RootCAs != nil {
+				t.Errorf("expected the system roots to be used")
+			}
+		})
+	}
+}
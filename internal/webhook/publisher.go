@@ -0,0 +1,185 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	handler "github.com/project-alvarium/alvarium-sdk-go/internal/annotators/http/handler"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/tlsconfig"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultTimeout          int = 5000
+	defaultMaxRetries       int = 3
+	defaultRetryInterval    int = 500
+	defaultMaxRetryInterval int = 30000
+)
+
+// defaultSignatureFields match the components verified by the HTTP PKI annotator
+var defaultSignatureFields = []string{string(contracts.Method), string(contracts.Path), string(contracts.Authority),
+	contracts.HttpContentType, contracts.ContentLength}
+
+type webhookPublisher struct {
+	cfg    config.WebhookConfig
+	signer interfaces.SignatureProvider
+	logger interfaces.Logger
+	client *http.Client
+}
+
+// NewWebhookPublisher validates the configuration and prepares a publisher. The signature provider is only used when
+// cfg.Signature is set and must match the algorithm of the configured private key.
+func NewWebhookPublisher(cfg config.WebhookConfig, signer interfaces.SignatureProvider,
+	logger interfaces.Logger) (interfaces.StreamProvider, error) {
+	if len(cfg.Urls) == 0 {
+		return nil, errors.New("at least one webhook URL must be provided")
+	}
+	if cfg.Signature != nil && signer == nil {
+		return nil, errors.New("webhook signature configured without a signature provider")
+	}
+	if cfg.Timeout == 0 {
+		cfg.Timeout = defaultTimeout
+	}
+	if cfg.MaxRetries == 0 {
+		cfg.MaxRetries = defaultMaxRetries
+	} else if cfg.MaxRetries < 0 {
+		cfg.MaxRetries = 0
+	}
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	if cfg.MaxRetryInterval == 0 {
+		cfg.MaxRetryInterval = defaultMaxRetryInterval
+	}
+	if len(cfg.SignatureFields) == 0 {
+		cfg.SignatureFields = defaultSignatureFields
+	}
+
+	tlsCfg, err := tlsconfig.New(cfg.Tls)
+	if err != nil {
+		return nil, err
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if tlsCfg != nil {
+		transport.TLSClientConfig = tlsCfg
+	}
+
+	p := webhookPublisher{
+		cfg:    cfg,
+		signer: signer,
+		logger: logger,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   time.Millisecond * time.Duration(cfg.Timeout),
+		},
+	}
+	return &p, nil
+}
+
+// Connect is a no-op since every publish is an independent HTTP request.
+func (p *webhookPublisher) Connect() error {
+	return nil
+}
+
+// Publish POSTs the message to every configured URL. Connection failures and responses indicating a transient
+// condition (408, 429 and 5xx) are retried with exponential backoff, any other non-2xx response fails immediately.
+func (p *webhookPublisher) Publish(msg message.PublishWrapper) error {
+	b, _ := json.Marshal(msg)
+
+	var errs []error
+	for _, url := range p.cfg.Urls {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("attempting publish, url %s %s", url, string(b)))
+		err := p.deliver(url, b)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("url %s: %w", url, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (p *webhookPublisher) Close() error {
+	p.client.CloseIdleConnections()
+	return nil
+}
+
+func (p *webhookPublisher) deliver(url string, body []byte) error {
+	backoff := p.cfg.RetryInterval
+	for attempt := 0; ; attempt++ {
+		retry, err := p.post(url, body)
+		if err == nil || !retry || attempt >= p.cfg.MaxRetries {
+			return err
+		}
+
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("webhook delivery failed, retrying in %dms: %s", backoff, err.Error()))
+		time.Sleep(time.Millisecond * time.Duration(backoff))
+		backoff *= 2
+		if backoff > p.cfg.MaxRetryInterval {
+			backoff = p.cfg.MaxRetryInterval
+		}
+	}
+}
+
+// post sends a single request and reports whether a failure is worth retrying.
+func (p *webhookPublisher) post(url string, body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	for k, v := range p.cfg.Headers {
+		req.Header.Set(k, v)
+	}
+	req.Header.Set(contracts.HttpContentType, string(contracts.ContentTypeJSON))
+	req.Header.Set(contracts.ContentLength, strconv.Itoa(len(body)))
+
+	if p.cfg.Signature != nil {
+		// Signed on every attempt so that the created timestamp reflects the actual send time
+		h := handler.NewSignatureRequestHandler(req, p.signer)
+		err = h.AddSignatureHeaders(time.Now(), p.cfg.SignatureFields, *p.cfg.Signature)
+		if err != nil {
+			return false, fmt.Errorf("failed to sign request: %w", err)
+		}
+		// The handler does not report failures of the signature provider itself, so never send an unsigned request
+		if req.Header.Get("Signature") == "" {
+			return false, errors.New("failed to sign request, check the configured private key")
+		}
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return true, err
+	}
+	defer resp.Body.Close()
+	// Drain the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retry := resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= 500
+	return retry, fmt.Errorf("unexpected response status %s", resp.Status)
+}
@@ -0,0 +1,136 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	handler "github.com/project-alvarium/alvarium-sdk-go/internal/annotators/http/handler"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/signprovider/ed25519"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestWebhookPublisherRetry(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	msg := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+
+	tests := []struct {
+		name        string
+		statuses    []int // response status for each successive attempt, the last one repeats
+		maxRetries  int
+		expectCalls int32
+		expectError bool
+	}{
+		{"accepted first attempt", []int{http.StatusAccepted}, 0, 1, false},
+		{"recovers from server errors", []int{http.StatusServiceUnavailable, http.StatusBadGateway, http.StatusOK}, 0, 3, false},
+		{"retries exhausted", []int{http.StatusTooManyRequests}, 2, 3, true},
+		{"retries disabled", []int{http.StatusInternalServerError}, -1, 1, true},
+		{"client error not retried", []int{http.StatusBadRequest}, 0, 1, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(atomic.AddInt32(&calls, 1))
+				if n > len(tt.statuses) {
+					n = len(tt.statuses)
+				}
+				w.WriteHeader(tt.statuses[n-1])
+			}))
+			defer srv.Close()
+
+			cfg := config.WebhookConfig{Urls: []string{srv.URL}, MaxRetries: tt.maxRetries, RetryInterval: 1}
+			p, err := NewWebhookPublisher(cfg, nil, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			err = p.Publish(msg)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if calls != tt.expectCalls {
+				t.Errorf("expected %d requests, got %d", tt.expectCalls, calls)
+			}
+		})
+	}
+}
+
+func TestWebhookPublisherSignature(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	keys := config.SignatureInfo{
+		PublicKey:  config.KeyInfo{Type: contracts.KeyEd25519, Path: "../../test/keys/ed25519/public.key"},
+		PrivateKey: config.KeyInfo{Type: contracts.KeyEd25519, Path: "../../test/keys/ed25519/private.key"},
+	}
+	signer := ed25519.New()
+
+	var received message.PublishWrapper
+	verified := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &received)
+
+		// The server side request URL is relative, restore the absolute form the client signed
+		r.URL.Scheme = "http"
+		r.URL.Host = r.Host
+		parsed, err := handler.ParseSignature(r)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		verified, _ = signer.Verify(keys.PublicKey, []byte(parsed.Seed), []byte(parsed.Signature))
+	}))
+	defer srv.Close()
+
+	cfg := config.WebhookConfig{
+		Urls:      []string{srv.URL + "/annotations"},
+		Headers:   map[string]string{"Authorization": "Bearer token"},
+		Signature: &keys,
+	}
+	p, err := NewWebhookPublisher(cfg, signer, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	msg := message.PublishWrapper{Action: message.ActionBroadcast, MessageType: "string", Content: []byte("topic")}
+	err = p.Publish(msg)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !verified {
+		t.Error("request signature not verified")
+	}
+	if received.Action != msg.Action || string(received.Content) != string(msg.Content) {
+		t.Errorf("unexpected message received %v", received)
+	}
+
+	// The publisher shares the SignatureInfo, so this breaks signing of subsequent requests
+	keys.PrivateKey.Path = "./missing.key"
+	err = p.Publish(msg)
+	if err == nil {
+		t.Error("expected error publishing with missing private key")
+	}
+}
@@ -0,0 +1,154 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// annotatorResult holds the outcome of a single annotator. done is false when the annotator was never run because
+// the action was cancelled or aborted beforehand.
+type annotatorResult struct {
+	annotation contracts.Annotation
+	err        error
+	done       bool
+}
+
+// annotate passes the data through each of the configured annotators according to SdkInfo.Execution. Regardless
+// of execution mode, the resulting list preserves the order in which annotators were supplied to NewSdk.
+//
+// Under the fail-fast policy the failure that aborted the action is returned as an AnnotatorError. In concurrent
+// mode that is the first annotator to fail for a reason other than the resulting cancellation. Under
+// the partial policy the successful annotations are returned along with a PartialAnnotationError describing the
+// failures, which the caller is expected to publish before reporting.
+func (s *sdk) annotate(ctx context.Context, action message.SdkAction, data []byte) (contracts.AnnotationList, *PartialAnnotationError, error) {
+	failFast := s.cfg.Execution.FailurePolicy != contracts.CollectPartial
+
+	var results []annotatorResult
+	cause := -1
+	if s.cfg.Execution.Mode == contracts.ConcurrentExecution {
+		results, cause = s.runConcurrent(ctx, data, failFast)
+	} else {
+		results = s.runSequential(ctx, data, failFast)
+	}
+
+	if ctx.Err() != nil {
+		return contracts.AnnotationList{}, nil, fmt.Errorf("%s: %w", action, ctx.Err())
+	}
+	if failFast && cause >= 0 {
+		return contracts.AnnotationList{}, nil, s.annotatorError(action, cause, results[cause].err)
+	}
+
+	var list contracts.AnnotationList
+	var failures []*AnnotatorError
+	for i, r := range results {
+		if !r.done {
+			continue
+		}
+		if r.err != nil {
+			failure := s.annotatorError(action, i, r.err)
+			if failFast {
+				return contracts.AnnotationList{}, nil, failure
+			}
+			failures = append(failures, failure)
+			continue
+		}
+		list.Items = append(list.Items, r.annotation)
+	}
+
+	if len(failures) > 0 {
+		return list, &PartialAnnotationError{Action: action, Errors: failures}, nil
+	}
+	return list, nil, nil
+}
+
+func (s *sdk) annotatorError(action message.SdkAction, i int, err error) *AnnotatorError {
+	return &AnnotatorError{Action: action, Index: i, Annotator: fmt.Sprintf("%T", s.annotators[i]), Err: err}
+}
+
+func (s *sdk) runSequential(ctx context.Context, data []byte, failFast bool) []annotatorResult {
+	results := make([]annotatorResult, len(s.annotators))
+	for i, a := range s.annotators {
+		if ctx.Err() != nil {
+			break
+		}
+		annotation, err := a.Do(ctx, data)
+		results[i] = annotatorResult{annotation: annotation, err: err, done: true}
+		if err != nil && failFast {
+			break
+		}
+	}
+	return results
+}
+
+// runConcurrent also returns the index of the first annotator to fail with an error other than a context error, -1 if
+// none did. Under fail-fast that failure cancels the others, which may then fail with context.Canceled.
+func (s *sdk) runConcurrent(ctx context.Context, data []byte, failFast bool) ([]annotatorResult, int) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	limit := s.cfg.Execution.MaxConcurrency
+	if limit <= 0 || limit > len(s.annotators) {
+		limit = len(s.annotators)
+	}
+	sem := make(chan struct{}, limit)
+
+	results := make([]annotatorResult, len(s.annotators))
+	cause := -1
+	var mutex sync.Mutex // guards cause
+	var wg sync.WaitGroup
+	for i, a := range s.annotators {
+		wg.Add(1)
+		go func(i int, a interfaces.Annotator) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+			if ctx.Err() != nil {
+				return
+			}
+
+			annotation, err := a.Do(ctx, data)
+			// Each goroutine writes only its own index, so no further synchronization is needed
+			results[i] = annotatorResult{annotation: annotation, err: err, done: true}
+			if err == nil {
+				return
+			}
+			if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+				mutex.Lock()
+				if cause < 0 {
+					cause = i
+				}
+				mutex.Unlock()
+			}
+			if failFast {
+				cancel()
+			}
+		}(i, a)
+	}
+	wg.Wait()
+	return results, cause
+}
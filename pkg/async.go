@@ -0,0 +1,186 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultQueueSize    int = 1024
+	defaultFlushTimeout int = 5000
+)
+
+// asyncStream decorates a StreamProvider with a bounded in-memory queue. Publish only enqueues the message; worker
+// goroutines forward queued messages to the wrapped stream. Since the caller has already returned by then, failures
+// of the wrapped stream are written to the logger.
+type asyncStream struct {
+	cfg    config.AsyncInfo
+	stream interfaces.StreamProvider
+	logger interfaces.Logger
+
+	queue     chan message.PublishWrapper
+	mutex     sync.RWMutex // guards closed so that Publish never sends on a closed queue
+	closed    bool
+	closing   chan struct{} // closed as soon as Close begins, releasing producers blocked on a full queue
+	closeOnce sync.Once
+	abort     chan struct{}
+	workers   sync.WaitGroup
+}
+
+func newAsyncStream(cfg config.AsyncInfo, stream interfaces.StreamProvider, logger interfaces.Logger) *asyncStream {
+	if cfg.QueueSize == 0 {
+		cfg.QueueSize = defaultQueueSize
+	}
+	if cfg.Workers == 0 {
+		cfg.Workers = 1
+	}
+	if cfg.Overflow == "" {
+		cfg.Overflow = contracts.OverflowBlock
+	}
+	if cfg.FlushTimeout == 0 {
+		cfg.FlushTimeout = defaultFlushTimeout
+	}
+
+	return &asyncStream{
+		cfg:     cfg,
+		stream:  stream,
+		logger:  logger,
+		queue:   make(chan message.PublishWrapper, cfg.QueueSize),
+		closing: make(chan struct{}),
+		abort:   make(chan struct{}),
+	}
+}
+
+// Connect connects the wrapped stream and starts the workers that drain the queue.
+func (p *asyncStream) Connect() error {
+	err := p.stream.Connect()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < p.cfg.Workers; i++ {
+		p.workers.Add(1)
+		go p.drain()
+	}
+	return nil
+}
+
+// Publish enqueues the message according to the configured overflow policy. Once Close has begun, messages are
+// rejected with ErrStreamClosed, including those of producers waiting for space in a full queue.
+func (p *asyncStream) Publish(msg message.PublishWrapper) error {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	select {
+	case <-p.closing:
+		return ErrStreamClosed
+	default:
+	}
+
+	switch p.cfg.Overflow {
+	case contracts.OverflowDropNewest:
+		select {
+		case p.queue <- msg:
+		default:
+			return ErrQueueFull
+		}
+	case contracts.OverflowDropOldest:
+		for {
+			select {
+			case p.queue <- msg:
+				return nil
+			default:
+			}
+			select {
+			case dropped := <-p.queue:
+				p.logger.Write(slog.LevelWarn, fmt.Sprintf("async queue full, dropped oldest %s message", dropped.Action))
+			default:
+			}
+		}
+	default:
+		// Close needs the write lock, so waiting must end as soon as it begins
+		select {
+		case p.queue <- msg:
+		case <-p.closing:
+			return ErrStreamClosed
+		}
+	}
+	return nil
+}
+
+// Close stops accepting messages and waits up to FlushTimeout for the queue to drain before closing the wrapped
+// stream. Messages still queued after the timeout are discarded.
+func (p *asyncStream) Close() error {
+	p.closeOnce.Do(func() {
+		close(p.closing)
+	})
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	close(p.queue)
+	p.mutex.Unlock()
+
+	p.logger.Write(slog.LevelDebug, fmt.Sprintf("flushing %d queued messages", len(p.queue)))
+
+	done := make(chan struct{})
+	go func() {
+		p.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Millisecond * time.Duration(p.cfg.FlushTimeout)):
+		// Don't wait on a publish that is already in flight, closing the wrapped stream should release it
+		close(p.abort)
+	}
+	return p.stream.Close()
+}
+
+func (p *asyncStream) drain() {
+	defer p.workers.Done()
+
+	discarded := 0
+	for msg := range p.queue {
+		select {
+		case <-p.abort:
+			discarded++
+			continue
+		default:
+		}
+
+		err := p.stream.Publish(msg)
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("async publish of %s message failed: %s", msg.Action, err.Error()))
+		}
+	}
+
+	if discarded > 0 {
+		p.logger.Error(fmt.Sprintf("flush timeout exceeded, discarded %d queued messages", discarded))
+	}
+}
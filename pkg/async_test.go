@@ -0,0 +1,217 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// gatedStream blocks every Publish until the gate is opened, signalling on started when a publish begins.
+type gatedStream struct {
+	started   chan struct{}
+	gate      chan struct{}
+	once      sync.Once
+	mutex     sync.Mutex
+	published []message.SdkAction
+}
+
+func newGatedStream() *gatedStream {
+	return &gatedStream{started: make(chan struct{}, 16), gate: make(chan struct{})}
+}
+
+func (p *gatedStream) Connect() error {
+	return nil
+}
+
+func (p *gatedStream) Publish(msg message.PublishWrapper) error {
+	p.started <- struct{}{}
+	<-p.gate
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.published = append(p.published, msg.Action)
+	return nil
+}
+
+func (p *gatedStream) Close() error {
+	p.open()
+	return nil
+}
+
+func (p *gatedStream) open() {
+	p.once.Do(func() { close(p.gate) })
+}
+
+func (p *gatedStream) actions() []message.SdkAction {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]message.SdkAction{}, p.published...)
+}
+
+func TestAsyncStreamOverflow(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	first := message.PublishWrapper{Action: message.ActionCreate}
+	second := message.PublishWrapper{Action: message.ActionMutate}
+	third := message.PublishWrapper{Action: message.ActionTransit}
+
+	tests := []struct {
+		name          string
+		overflow      contracts.OverflowPolicy
+		expectFull    bool
+		expectActions []message.SdkAction
+	}{
+		{"drop newest", contracts.OverflowDropNewest, true, []message.SdkAction{first.Action, second.Action}},
+		{"drop oldest", contracts.OverflowDropOldest, false, []message.SdkAction{first.Action, third.Action}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := newGatedStream()
+			p := newAsyncStream(config.AsyncInfo{QueueSize: 1, Overflow: tt.overflow}, stream, logger)
+			if err := p.Connect(); err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			// The worker takes the first message and blocks in the stream, the second fills the queue
+			_ = p.Publish(first)
+			<-stream.started
+			_ = p.Publish(second)
+
+			err := p.Publish(third)
+			if errors.Is(err, ErrQueueFull) != tt.expectFull {
+				t.Errorf("unexpected result publishing to full queue: %v", err)
+			}
+
+			stream.open()
+			if err := p.Close(); err != nil {
+				t.Fatalf(err.Error())
+			}
+			actions := stream.actions()
+			if len(actions) != len(tt.expectActions) {
+				t.Fatalf("expected %v published, received %v", tt.expectActions, actions)
+			}
+			for i := range actions {
+				if actions[i] != tt.expectActions[i] {
+					t.Errorf("expected %v published, received %v", tt.expectActions, actions)
+				}
+			}
+		})
+	}
+}
+
+func TestAsyncStreamFlushOnClose(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	stream := newGatedStream()
+	stream.open()
+	p := newAsyncStream(config.AsyncInfo{QueueSize: 10, Workers: 2}, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for i := 0; i < 10; i++ {
+		if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate}); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(stream.actions()) != 10 {
+		t.Errorf("expected 10 messages flushed, received %d", len(stream.actions()))
+	}
+
+	err := p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	if !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("expected ErrStreamClosed, received %v", err)
+	}
+}
+
+func TestAsyncStreamFlushTimeout(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	stream := newGatedStream()
+	p := newAsyncStream(config.AsyncInfo{QueueSize: 10, FlushTimeout: 10}, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for i := 0; i < 3; i++ {
+		_ = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	}
+	<-stream.started
+
+	done := make(chan error)
+	go func() { done <- p.Close() }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("close did not honour flush timeout")
+	}
+
+	p.workers.Wait()
+	if len(stream.actions()) != 1 {
+		t.Errorf("expected only the in-flight message to be published, received %d", len(stream.actions()))
+	}
+}
+
+func TestAsyncStreamCloseBlockedProducer(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	stream := newGatedStream()
+	p := newAsyncStream(config.AsyncInfo{QueueSize: 1, FlushTimeout: 10}, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// The worker blocks in the stream with the first message and the second fills the queue
+	_ = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	<-stream.started
+	_ = p.Publish(message.PublishWrapper{Action: message.ActionMutate})
+
+	blocked := make(chan error)
+	go func() { blocked <- p.Publish(message.PublishWrapper{Action: message.ActionTransit}) }()
+	select {
+	case err := <-blocked:
+		t.Fatalf("expected publish to block on the full queue, received %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	closed := make(chan error)
+	go func() { closed <- p.Close() }()
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrStreamClosed) {
+			t.Errorf("expected ErrStreamClosed for the blocked producer, received %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("blocked producer was not released by close")
+	}
+	select {
+	case err := <-closed:
+		if err != nil {
+			t.Fatalf(err.Error())
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("close did not honour flush timeout")
+	}
+}
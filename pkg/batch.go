@@ -0,0 +1,219 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultBatchCount  int = 100
+	defaultBatchLinger int = 1000
+)
+
+// envelopeOverhead is the length of a JSON encoded envelope besides its base64 encoded content
+var envelopeOverhead = len(fmt.Sprintf(`{"action":"%s","messageType":"%s","content":""}`, message.ActionBatch, message.BatchType))
+
+// batchStream decorates a StreamProvider, grouping messages into message.Batch envelopes. Publish only adds the
+// message to the open batch; a background goroutine publishes batches to the wrapped stream once they are full or
+// have lingered. Since the caller has already returned by then, failures of the wrapped stream are written to the
+// logger.
+type batchStream struct {
+	cfg    config.BatchInfo
+	stream interfaces.StreamProvider
+	logger interfaces.Logger
+
+	mutex      sync.Mutex // guards the open batch and the fields below
+	changed    *sync.Cond // broadcast when a batch is sealed or taken for publishing and on close
+	items      [][]byte   // JSON encoded messages of the open batch
+	size       int        // total length of items
+	generation int        // incremented as each batch is sealed so that a stale linger timer leaves the next one alone
+	timer      *time.Timer
+	sealed     [][][]byte // batches waiting to be published, oldest first
+	started    bool
+	closed     bool
+
+	done chan struct{}
+}
+
+func newBatchStream(cfg config.BatchInfo, stream interfaces.StreamProvider, logger interfaces.Logger) *batchStream {
+	if cfg.MaxCount == 0 {
+		cfg.MaxCount = defaultBatchCount
+	}
+	if cfg.Linger == 0 {
+		cfg.Linger = defaultBatchLinger
+	}
+
+	p := batchStream{
+		cfg:    cfg,
+		stream: stream,
+		logger: logger,
+		done:   make(chan struct{}),
+	}
+	p.changed = sync.NewCond(&p.mutex)
+	return &p
+}
+
+// Connect connects the wrapped stream and starts publishing sealed batches.
+func (p *batchStream) Connect() error {
+	err := p.stream.Connect()
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.started = true
+	p.mutex.Unlock()
+	go p.drain()
+	return nil
+}
+
+// Publish adds the message to the open batch, sealing the batch first if the message would take it past MaxBytes,
+// and after if it then holds MaxCount messages. A message larger than MaxBytes on its own is sent in a batch of one.
+// Publish blocks while the previous batch is still waiting to be published, and fails before Connect so that sealed
+// batches never pile up.
+func (p *batchStream) Publish(msg message.PublishWrapper) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		return ErrStreamClosed
+	}
+	if !p.started {
+		return ErrStreamNotConnected
+	}
+	for !p.closed && len(p.sealed) > 0 {
+		p.changed.Wait()
+	}
+	if p.closed {
+		return ErrStreamClosed
+	}
+
+	if len(p.items) > 0 && p.exceeds(p.size+len(b), len(p.items)+1) {
+		p.seal()
+	}
+	p.items = append(p.items, b)
+	p.size += len(b)
+
+	if len(p.items) >= p.cfg.MaxCount {
+		p.seal()
+	} else if len(p.items) == 1 {
+		generation := p.generation
+		p.timer = time.AfterFunc(time.Millisecond*time.Duration(p.cfg.Linger), func() {
+			p.mutex.Lock()
+			defer p.mutex.Unlock()
+			if p.generation == generation && !p.closed {
+				p.seal()
+			}
+		})
+	}
+	return nil
+}
+
+// Close publishes the open batch and waits for all sealed batches to be published before closing the wrapped
+// stream.
+func (p *batchStream) Close() error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	if len(p.items) > 0 {
+		p.logger.Write(slog.LevelDebug, fmt.Sprintf("flushing batch of %d messages", len(p.items)))
+		p.seal()
+	}
+	p.closed = true
+	p.changed.Broadcast()
+	started := p.started
+	p.mutex.Unlock()
+
+	if started {
+		<-p.done
+	}
+	return p.stream.Close()
+}
+
+// exceeds reports whether an envelope holding count messages of total length size is larger than MaxBytes
+func (p *batchStream) exceeds(size int, count int) bool {
+	if p.cfg.MaxBytes == 0 {
+		return false
+	}
+	// The content is {"items":[...]} with the messages separated by commas
+	content := len(`{"items":[]}`) + size + count - 1
+	return envelopeOverhead+base64.StdEncoding.EncodedLen(content) > p.cfg.MaxBytes
+}
+
+// seal queues the open batch for publishing. Must be called with mutex held.
+func (p *batchStream) seal() {
+	p.sealed = append(p.sealed, p.items)
+	p.items = nil
+	p.size = 0
+	p.generation++
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	p.changed.Broadcast()
+}
+
+// drain publishes sealed batches in order until the stream is closed and none are left. The mutex is released while
+// publishing so that Publish, the linger timer and Close never wait on the wrapped stream while holding it.
+func (p *batchStream) drain() {
+	defer close(p.done)
+
+	for {
+		p.mutex.Lock()
+		for len(p.sealed) == 0 && !p.closed {
+			p.changed.Wait()
+		}
+		if len(p.sealed) == 0 {
+			p.mutex.Unlock()
+			return
+		}
+		items := p.sealed[0]
+		p.sealed = p.sealed[1:]
+		p.changed.Broadcast()
+		p.mutex.Unlock()
+
+		var content bytes.Buffer
+		content.WriteString(`{"items":[`)
+		content.Write(bytes.Join(items, []byte(",")))
+		content.WriteString(`]}`)
+
+		err := p.stream.Publish(message.PublishWrapper{
+			Action:      message.ActionBatch,
+			MessageType: message.BatchType,
+			Content:     content.Bytes(),
+		})
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("publish of batch of %d messages failed: %s", len(items), err.Error()))
+		}
+	}
+}
@@ -0,0 +1,166 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/mock"
+)
+
+func TestBatchStream(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	messages := make([]message.PublishWrapper, 7)
+	for i := range messages {
+		a := contracts.NewAnnotation(fmt.Sprintf("key%d", i), contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+		a.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC) // messages of equal size
+		b, _ := json.Marshal(contracts.AnnotationList{Items: []contracts.Annotation{a}})
+		messages[i] = message.PublishWrapper{Action: message.ActionCreate, MessageType: message.AnnotationListType, Content: b}
+	}
+
+	// Room for exactly three messages per envelope
+	three, _ := message.NewBatch(messages[:3])
+	b, _ := json.Marshal(three)
+	maxBytes := len(b)
+
+	tests := []struct {
+		name        string
+		cfg         config.BatchInfo
+		publishes   int
+		expectSizes []int // messages per envelope
+		beforeClose int   // envelopes expected to be published before Close
+	}{
+		{"max count", config.BatchInfo{MaxCount: 3, Linger: 60000}, 7, []int{3, 3, 1}, 2},
+		{"max bytes", config.BatchInfo{MaxBytes: maxBytes, Linger: 60000}, 7, []int{3, 3, 1}, 2},
+		{"oversized message", config.BatchInfo{MaxBytes: 10, Linger: 60000}, 3, []int{1, 1, 1}, 2},
+		{"linger", config.BatchInfo{MaxCount: 10, Linger: 50}, 4, []int{4}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := mock.NewMockPublisher(config.MockStreamConfig{}, logger)
+			p := newBatchStream(tt.cfg, stream, logger)
+			if err := p.Connect(); err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			for i := 0; i < tt.publishes; i++ {
+				if err := p.Publish(messages[i]); err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if _, err := stream.WaitForMessages(ctx, tt.beforeClose); err != nil {
+				t.Fatalf("timed out waiting for %d envelopes", tt.beforeClose)
+			}
+
+			if err := p.Close(); err != nil {
+				t.Fatalf(err.Error())
+			}
+			if err := p.Publish(messages[0]); !errors.Is(err, ErrStreamClosed) {
+				t.Errorf("expected ErrStreamClosed publishing after Close, received %v", err)
+			}
+
+			records := stream.Records()
+			if len(records) != len(tt.expectSizes) {
+				t.Fatalf("expected %d envelopes, received %d", len(tt.expectSizes), len(records))
+			}
+			next := 0
+			for i, r := range records {
+				items, ok := r.Wrapper.Batch()
+				if !ok || len(items) != tt.expectSizes[i] {
+					t.Fatalf("unexpected envelope %d %s", i, string(r.Wrapper.Content))
+				}
+				// The envelope is identical to the one built by message.NewBatch
+				envelope, _ := message.NewBatch(messages[next : next+len(items)])
+				if !bytes.Equal(envelope.Content, r.Wrapper.Content) {
+					t.Errorf("unexpected content of envelope %d %s", i, string(r.Wrapper.Content))
+				}
+				b, _ := json.Marshal(r.Wrapper)
+				if tt.cfg.MaxBytes >= maxBytes && len(b) > tt.cfg.MaxBytes {
+					t.Errorf("envelope %d of %d bytes exceeds maxBytes", i, len(b))
+				}
+				next += len(items)
+			}
+		})
+	}
+}
+
+func TestBatchStreamNotConnected(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	p := newBatchStream(config.BatchInfo{MaxCount: 1}, newGatedStream(), logger)
+
+	err := p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	if !errors.Is(err, ErrStreamNotConnected) {
+		t.Errorf("expected ErrStreamNotConnected, received %v", err)
+	}
+	if err = p.Close(); err != nil {
+		t.Errorf(err.Error())
+	}
+}
+
+func TestBatchStreamCloseWhileBlocked(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	stream := newGatedStream()
+	p := newBatchStream(config.BatchInfo{MaxCount: 1, Linger: 60000}, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// The first envelope blocks in the stream and the second waits to be published
+	_ = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	<-stream.started
+	_ = p.Publish(message.PublishWrapper{Action: message.ActionMutate})
+
+	blocked := make(chan error)
+	go func() { blocked <- p.Publish(message.PublishWrapper{Action: message.ActionTransit}) }()
+	select {
+	case err := <-blocked:
+		t.Fatalf("expected publish to wait for the sealed batch, received %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Close must not wait on the stream to release the producer
+	closed := make(chan error)
+	go func() { closed <- p.Close() }()
+	select {
+	case err := <-blocked:
+		if !errors.Is(err, ErrStreamClosed) {
+			t.Errorf("expected ErrStreamClosed for the waiting producer, received %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("waiting producer was not released by close")
+	}
+
+	stream.open()
+	if err := <-closed; err != nil {
+		t.Fatalf(err.Error())
+	}
+	actions := stream.actions()
+	if len(actions) != 2 || actions[0] != message.ActionBatch || actions[1] != message.ActionBatch {
+		t.Errorf("unexpected envelopes published %v", actions)
+	}
+}
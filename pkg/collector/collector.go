@@ -0,0 +1,143 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package collector is a reference implementation of the service receiving annotations from SDK instances publishing
+// with the grpc stream provider.
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/ingest"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/peer"
+)
+
+// Collector passes the messages published to it to a handler and acknowledges each of them with the handler's
+// outcome, in the order they were received on a stream.
+type Collector struct {
+	handler interfaces.DeliveryHandler
+	onError interfaces.ErrorHandler
+	logger  interfaces.Logger
+}
+
+// NewCollector returns a collector delivering messages to handler. Returning nil from handler accepts a message,
+// returning an error rejects it, which the publishing SDK instance reports as a failed publish. Messages that cannot
+// be decoded or are rejected are also reported to onError, or written to the logger if it is nil.
+func NewCollector(handler interfaces.DeliveryHandler, onError interfaces.ErrorHandler,
+	logger interfaces.Logger) *Collector {
+	c := Collector{
+		handler: handler,
+		onError: onError,
+		logger:  logger,
+	}
+	if c.onError == nil {
+		c.onError = func(err error) {
+			logger.Error(err.Error())
+		}
+	}
+	return &c
+}
+
+// Register adds the ingestion service to a gRPC server, which may serve other services as well.
+func (c *Collector) Register(server grpc.ServiceRegistrar) {
+	ingest.RegisterIngestServer(server, &service{collector: c})
+}
+
+// Serve accepts connections on listener until ctx is cancelled, then stops gracefully. The options configure the
+// gRPC server, e.g. its credentials.
+func (c *Collector) Serve(ctx context.Context, listener net.Listener, opts ...grpc.ServerOption) error {
+	server := grpc.NewServer(opts...)
+	c.Register(server)
+
+	stopped := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			server.GracefulStop()
+		case <-stopped:
+		}
+	}()
+	defer close(stopped)
+	return server.Serve(listener)
+}
+
+// service implements the generated ingestion service, which is kept out of the collector's API
+type service struct {
+	ingest.UnimplementedIngestServer
+	collector *Collector
+}
+
+// Publish delivers the messages received on a stream in turn. Deliveries are identified by the address of the
+// publishing peer, carry the sequence assigned by the publisher and the time they were received. The stream's context,
+// passed to the handler, holds the metadata sent by the publisher.
+func (s *service) Publish(stream ingest.Ingest_PublishServer) error {
+	source := "unknown"
+	if p, ok := peer.FromContext(stream.Context()); ok {
+		source = p.Addr.String()
+	}
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		ack := &ingest.PublishAck{Sequence: req.GetSequence(), Accepted: true}
+		err = s.collector.deliver(stream.Context(), source, req)
+		if err != nil {
+			s.collector.onError(err)
+			ack.Accepted = false
+			ack.Error = errors.Unwrap(err).Error()
+		}
+		err = stream.Send(ack)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Collector) deliver(ctx context.Context, source string, req *ingest.PublishRequest) error {
+	msg, err := ingest.ToWrapper(req.GetWrapper())
+	if err != nil {
+		return &message.DeliveryError{Source: source, Err: err}
+	}
+	payload, _ := json.Marshal(msg)
+
+	deliveries, err := message.NewDeliveries(payload, source)
+	if err != nil {
+		return &message.DeliveryError{Source: source, Payload: payload, Err: err}
+	}
+
+	received := time.Now()
+	for _, d := range deliveries {
+		d.Sequence = req.GetSequence()
+		d.Timestamp = received
+		err = c.handler(ctx, d)
+		if err != nil {
+			return &message.DeliveryError{Source: source, Payload: payload, Err: err}
+		}
+	}
+	return nil
+}
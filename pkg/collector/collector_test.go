@@ -0,0 +1,135 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package collector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/ingest"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCollector(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	var mutex sync.Mutex
+	var received []message.Delivery
+	var errs []error
+	handler := func(ctx context.Context, d message.Delivery) error {
+		if d.MessageType == "reject" {
+			return errors.New("rejected by handler")
+		}
+		mutex.Lock()
+		defer mutex.Unlock()
+		received = append(received, d)
+		return nil
+	}
+	onError := func(err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		errs = append(errs, err)
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- NewCollector(handler, onError, logger).Serve(ctx, listener)
+	}()
+
+	conn, err := grpc.Dial("bufnet", grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, s string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer conn.Close()
+	stream, err := ingest.NewIngestClient(conn).Publish(context.Background())
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	annotation := contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+	b, _ := json.Marshal(contracts.AnnotationList{Items: []contracts.Annotation{annotation}})
+	valid := ingest.FromWrapper(message.PublishWrapper{Action: message.ActionCreate, MessageType: message.AnnotationListType, Content: b})
+	invalidId := &ingest.PublishWrapper{
+		Action:      string(message.ActionCreate),
+		MessageType: message.AnnotationListType,
+		Content:     &ingest.PublishWrapper_Annotations{Annotations: &ingest.AnnotationList{Items: []*ingest.Annotation{{Id: "not a ulid"}}}},
+	}
+
+	tests := []struct {
+		name         string
+		wrapper      *ingest.PublishWrapper
+		expectAccept bool
+	}{
+		{"valid annotations", valid, true},
+		{"valid raw content", &ingest.PublishWrapper{Action: string(message.ActionPublish), MessageType: "string", Content: &ingest.PublishWrapper_Raw{Raw: []byte("raw")}}, true},
+		{"invalid action", &ingest.PublishWrapper{Action: "invalid", MessageType: "string"}, false},
+		{"invalid annotation id", invalidId, false},
+		{"rejected by handler", &ingest.PublishWrapper{Action: string(message.ActionPublish), MessageType: "reject"}, false},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := stream.Send(&ingest.PublishRequest{Sequence: uint64(i + 1), Wrapper: tt.wrapper})
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			ack, err := stream.Recv()
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if ack.GetSequence() != uint64(i+1) || ack.GetAccepted() != tt.expectAccept ||
+				(ack.GetError() == "") != tt.expectAccept {
+				t.Errorf("unexpected ack %v", ack)
+			}
+		})
+	}
+
+	mutex.Lock()
+	if len(received) != 2 || received[0].Annotations.Items[0].Key != "datakey" || received[0].Sequence != 1 ||
+		received[0].Source == "" {
+		t.Errorf("unexpected deliveries %v", received)
+	}
+	if len(errs) != 3 {
+		t.Errorf("expected 3 errors, received %v", errs)
+	}
+	mutex.Unlock()
+
+	// Serving stops once the stream ends and the context is cancelled
+	_ = stream.CloseSend()
+	cancel()
+	select {
+	case err = <-served:
+		if err != nil {
+			t.Errorf("unexpected error %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for the collector to stop")
+	}
+}
@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"gopkg.in/yaml.v3"
+)
+
+// AsyncInfo enables an in-memory queue between the SDK and its stream provider so that SDK methods return as soon
+// as annotations are enqueued. Worker goroutines drain the queue to the stream in the background.
+type AsyncInfo struct {
+	Enabled      bool                     `json:"enabled,omitempty" yaml:"enabled"`
+	QueueSize    int                      `json:"queueSize,omitempty" yaml:"queueSize"`       // Defaults to 1024
+	Workers      int                      `json:"workers,omitempty" yaml:"workers"`           // Defaults to 1, more than 1 does not preserve publish order
+	Overflow     contracts.OverflowPolicy `json:"overflow,omitempty" yaml:"overflow"`         // Defaults to block
+	FlushTimeout int                      `json:"flushTimeout,omitempty" yaml:"flushTimeout"` // Milliseconds allowed to drain the queue on shutdown, defaults to 5000
+}
+
+func (a *AsyncInfo) UnmarshalJSON(data []byte) (err error) {
+	type Alias AsyncInfo
+	x := Alias{}
+	if err = json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+
+	if err = validateAsync(AsyncInfo(x)); err != nil {
+		return err
+	}
+	*a = AsyncInfo(x)
+	return nil
+}
+
+func (a *AsyncInfo) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias AsyncInfo
+	x := Alias{}
+	if err = data.Decode(&x); err != nil {
+		return err
+	}
+
+	if err = validateAsync(AsyncInfo(x)); err != nil {
+		return err
+	}
+	*a = AsyncInfo(x)
+	return nil
+}
+
+func validateAsync(a AsyncInfo) error {
+	if a.Overflow != "" && !a.Overflow.Validate() {
+		return fmt.Errorf("invalid OverflowPolicy value provided %s", a.Overflow)
+	}
+	if a.QueueSize < 0 || a.Workers < 0 || a.FlushTimeout < 0 {
+		return fmt.Errorf("invalid AsyncInfo values provided queueSize %d workers %d flushTimeout %d",
+			a.QueueSize, a.Workers, a.FlushTimeout)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// BatchInfo groups messages into a single envelope, a message.Batch, before they are published so that streams
+// charging or limiting per message receive fewer of them. A batch is published once it holds MaxCount messages, once
+// it would grow past MaxBytes, or once its first message has waited for Linger. Batching cannot be combined with the
+// outbox, see SdkInfo.ValidateBatching.
+type BatchInfo struct {
+	Enabled  bool `json:"enabled,omitempty" yaml:"enabled"`
+	MaxCount int  `json:"maxCount,omitempty" yaml:"maxCount"` // Defaults to 100
+	MaxBytes int  `json:"maxBytes,omitempty" yaml:"maxBytes"` // Size limit of the JSON encoded envelope, zero disables
+	Linger   int  `json:"linger,omitempty" yaml:"linger"`     // Milliseconds a batch waits for more messages, defaults to 1000
+}
+
+func (b *BatchInfo) UnmarshalJSON(data []byte) (err error) {
+	type Alias BatchInfo
+	x := Alias{}
+	if err = json.Unmarshal(data, &x); err != nil {
+		return err
+	}
+
+	if err = validateBatch(BatchInfo(x)); err != nil {
+		return err
+	}
+	*b = BatchInfo(x)
+	return nil
+}
+
+func (b *BatchInfo) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias BatchInfo
+	x := Alias{}
+	if err = data.Decode(&x); err != nil {
+		return err
+	}
+
+	if err = validateBatch(BatchInfo(x)); err != nil {
+		return err
+	}
+	*b = BatchInfo(x)
+	return nil
+}
+
+func validateBatch(b BatchInfo) error {
+	if b.MaxCount < 0 || b.MaxBytes < 0 || b.Linger < 0 {
+		return fmt.Errorf("invalid BatchInfo values provided maxCount %d maxBytes %d linger %d",
+			b.MaxCount, b.MaxBytes, b.Linger)
+	}
+	return nil
+}
+
+// ValidateBatching rejects invalid batch values and batching in combination with the outbox, which would persist
+// envelopes only after the messages they group had been accepted. It is checked when SdkInfo is unmarshaled and again
+// when the SDK is bootstrapped, so that configuration built in code is covered too.
+func (s SdkInfo) ValidateBatching() error {
+	if !s.Batch.Enabled {
+		return nil
+	}
+	if err := validateBatch(s.Batch); err != nil {
+		return err
+	}
+	if s.Outbox.Enabled {
+		return errors.New("batch cannot be combined with outbox, batched messages would not be durable")
+	}
+	return nil
+}
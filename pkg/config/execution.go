@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"gopkg.in/yaml.v3"
+)
+
+// ExecutionInfo controls how the SDK runs its list of annotators for a single action. When omitted, annotators run
+// sequentially and the first failure aborts the action.
+type ExecutionInfo struct {
+	Mode           contracts.ExecutionMode `json:"mode,omitempty" yaml:"mode"`
+	FailurePolicy  contracts.FailurePolicy `json:"failurePolicy,omitempty" yaml:"failurePolicy"`
+	MaxConcurrency int                     `json:"maxConcurrency,omitempty" yaml:"maxConcurrency"` // Zero means one goroutine per annotator
+}
+
+func (e *ExecutionInfo) UnmarshalJSON(data []byte) (err error) {
+	type Alias ExecutionInfo
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateExecution(ExecutionInfo(a)); err != nil {
+		return err
+	}
+	*e = ExecutionInfo(a)
+	return nil
+}
+
+func (e *ExecutionInfo) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias ExecutionInfo
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateExecution(ExecutionInfo(a)); err != nil {
+		return err
+	}
+	*e = ExecutionInfo(a)
+	return nil
+}
+
+func validateExecution(e ExecutionInfo) error {
+	if e.Mode != "" && !e.Mode.Validate() {
+		return fmt.Errorf("invalid ExecutionMode value provided %s", e.Mode)
+	}
+	if e.FailurePolicy != "" && !e.FailurePolicy.Validate() {
+		return fmt.Errorf("invalid FailurePolicy value provided %s", e.FailurePolicy)
+	}
+	if e.MaxConcurrency < 0 {
+		return fmt.Errorf("invalid MaxConcurrency value provided %d", e.MaxConcurrency)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+	"gopkg.in/yaml.v3"
+)
+
+func TestExecutionInfoUnmarshal(t *testing.T) {
+	tests := []struct {
+		name        string
+		info        ExecutionInfo
+		expectError bool
+	}{
+		{"valid defaults", ExecutionInfo{}, false},
+		{"valid concurrent", ExecutionInfo{Mode: contracts.ConcurrentExecution, FailurePolicy: contracts.CollectPartial, MaxConcurrency: 4}, false},
+		{"valid sequential", ExecutionInfo{Mode: contracts.SequentialExecution, FailurePolicy: contracts.FailFast}, false},
+		{"invalid mode", ExecutionInfo{Mode: "invalid"}, true},
+		{"invalid failure policy", ExecutionInfo{FailurePolicy: "invalid"}, true},
+		{"invalid max concurrency", ExecutionInfo{MaxConcurrency: -1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b, _ := json.Marshal(tt.info)
+			var x ExecutionInfo
+			err := json.Unmarshal(b, &x)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err == nil && x != tt.info {
+				t.Errorf("ExecutionInfo mismatch expected %v received %v", tt.info, x)
+			}
+
+			y, _ := yaml.Marshal(tt.info)
+			var z ExecutionInfo
+			err = yaml.Unmarshal(y, &z)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err == nil && z != tt.info {
+				t.Errorf("ExecutionInfo mismatch expected %v received %v", tt.info, z)
+			}
+		})
+	}
+}
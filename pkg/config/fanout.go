@@ -0,0 +1,68 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"gopkg.in/yaml.v3"
+)
+
+// FanoutInfo determines when a publish to the list of streams in SdkInfo.Streams is considered successful. When
+// omitted, every stream must accept the annotations.
+type FanoutInfo struct {
+	Policy contracts.FanoutPolicy `json:"policy,omitempty" yaml:"policy"`
+	Quorum int                    `json:"quorum,omitempty" yaml:"quorum"` // Streams required under the quorum policy, defaults to a majority
+}
+
+func (f *FanoutInfo) UnmarshalJSON(data []byte) (err error) {
+	type Alias FanoutInfo
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateFanout(FanoutInfo(a)); err != nil {
+		return err
+	}
+	*f = FanoutInfo(a)
+	return nil
+}
+
+func (f *FanoutInfo) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias FanoutInfo
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateFanout(FanoutInfo(a)); err != nil {
+		return err
+	}
+	*f = FanoutInfo(a)
+	return nil
+}
+
+func validateFanout(f FanoutInfo) error {
+	if f.Policy != "" && !f.Policy.Validate() {
+		return fmt.Errorf("invalid FanoutPolicy value provided %s", f.Policy)
+	}
+	if f.Quorum < 0 {
+		return fmt.Errorf("invalid Quorum value provided %d", f.Quorum)
+	}
+	return nil
+}
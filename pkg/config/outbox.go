@@ -0,0 +1,73 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutboxInfo enables a durable on-disk outbox in front of the stream provider. Annotations are persisted before
+// publishing and only removed once the stream provider has accepted them, so they survive broker outages and
+// process restarts.
+type OutboxInfo struct {
+	Enabled          bool   `json:"enabled,omitempty" yaml:"enabled"`
+	Path             string `json:"path,omitempty" yaml:"path"`                         // Path is the directory holding pending messages
+	RetryInterval    int    `json:"retryInterval,omitempty" yaml:"retryInterval"`       // Initial retry backoff in milliseconds, defaults to 500
+	MaxRetryInterval int    `json:"maxRetryInterval,omitempty" yaml:"maxRetryInterval"` // Upper bound of the retry backoff in milliseconds, defaults to 30000
+	MaxAttempts      int    `json:"maxAttempts,omitempty" yaml:"maxAttempts"`           // Failed deliveries of a message before it may be set aside, defaults to 5
+}
+
+func (o *OutboxInfo) UnmarshalJSON(data []byte) (err error) {
+	type Alias OutboxInfo
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateOutbox(OutboxInfo(a)); err != nil {
+		return err
+	}
+	*o = OutboxInfo(a)
+	return nil
+}
+
+func (o *OutboxInfo) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias OutboxInfo
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateOutbox(OutboxInfo(a)); err != nil {
+		return err
+	}
+	*o = OutboxInfo(a)
+	return nil
+}
+
+func validateOutbox(o OutboxInfo) error {
+	if o.Enabled && o.Path == "" {
+		return errors.New("outbox path must be provided when the outbox is enabled")
+	}
+	if o.RetryInterval < 0 || o.MaxRetryInterval < 0 || o.MaxAttempts < 0 {
+		return fmt.Errorf("invalid OutboxInfo values provided retryInterval %d maxRetryInterval %d maxAttempts %d",
+			o.RetryInterval, o.MaxRetryInterval, o.MaxAttempts)
+	}
+	return nil
+}
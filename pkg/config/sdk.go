@@ -16,6 +16,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 
@@ -28,7 +29,13 @@ type SdkInfo struct {
 	Hash       HashInfo                   `json:"hash,omitempty" yaml:"hash"`
 	Signature  SignatureInfo              `json:"signature,omitempty" yaml:"signature"`
 	Stream     StreamInfo                 `json:"stream,omitempty" yaml:"stream"`
+	Streams    []StreamInfo               `json:"streams,omitempty" yaml:"streams"` // Publishes to several streams at once, replaces Stream
+	Fanout     FanoutInfo                 `json:"fanout,omitempty" yaml:"fanout"`
 	Layer      contracts.LayerType        `json:"layer,omitempty" yaml:"layer"`
+	Execution  ExecutionInfo              `json:"execution,omitempty" yaml:"execution"`
+	Async      AsyncInfo                  `json:"async,omitempty" yaml:"async"`
+	Outbox     OutboxInfo                 `json:"outbox,omitempty" yaml:"outbox"`
+	Batch      BatchInfo                  `json:"batch,omitempty" yaml:"batch"`
 }
 
 type LoggingInfo struct {
@@ -56,6 +63,13 @@ func (s *SdkInfo) UnmarshalJSON(data []byte) (err error) {
 		}
 	}
 
+	if a.Stream.Type != "" && len(a.Streams) > 0 {
+		return errors.New("only one of stream and streams may be provided")
+	}
+	if err = SdkInfo(*a).ValidateBatching(); err != nil {
+		return err
+	}
+
 	*s = SdkInfo(*a)
 	return nil
 }
@@ -82,9 +96,22 @@ func (s *SdkInfo) UnmarshalYAML(data *yaml.Node) (err error) {
 		}
 	}
 
+	if a.Stream.Type != "" && len(a.Streams) > 0 {
+		return errors.New("only one of stream and streams may be provided")
+	}
+	if err = SdkInfo(*a).ValidateBatching(); err != nil {
+		return err
+	}
+
 	s.Annotators = a.Annotators
 	s.Hash = a.Hash
 	s.Signature = a.Signature
 	s.Stream = a.Stream
+	s.Streams = a.Streams
+	s.Fanout = a.Fanout
+	s.Execution = a.Execution
+	s.Async = a.Async
+	s.Outbox = a.Outbox
+	s.Batch = a.Batch
 	return nil
 }
@@ -18,6 +18,10 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+	"gopkg.in/yaml.v3"
 )
 
 func TestSDKInfo_UnmarshalJSON(t *testing.T) {
@@ -32,3 +36,82 @@ func TestSDKInfo_UnmarshalJSON(t *testing.T) {
 		t.Fatalf(err.Error())
 	}
 }
+
+func TestSDKInfo_UnmarshalJSONStreams(t *testing.T) {
+	streams := `{"streams":[{"type":"console"},{"type":"mock","name":"backup","config":{"provider":{"host":"localhost","port":8080,"protocol":"http"}}}],
+		"fanout":{"policy":"quorum","quorum":1}}`
+	both := `{"stream":{"type":"console"},"streams":[{"type":"console"}]}`
+	badPolicy := `{"streams":[{"type":"console"}],"fanout":{"policy":"invalid"}}`
+
+	tests := []struct {
+		name        string
+		data        string
+		expectError bool
+	}{
+		{"valid streams", streams, false},
+		{"stream and streams", both, true},
+		{"invalid fanout policy", badPolicy, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg SdkInfo
+			err := json.Unmarshal([]byte(tt.data), &cfg)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err == nil {
+				if len(cfg.Streams) != 2 || cfg.Streams[1].Type != contracts.MockStream || cfg.Streams[1].Name != "backup" ||
+					cfg.Fanout.Policy != contracts.FanoutQuorum || cfg.Fanout.Quorum != 1 {
+					t.Errorf("unexpected streams config %v %v", cfg.Streams, cfg.Fanout)
+				}
+			}
+		})
+	}
+}
+
+func TestSDKInfo_UnmarshalBatch(t *testing.T) {
+	batch := `{"stream":{"type":"console"},"batch":{"enabled":true,"maxCount":50,"maxBytes":65536,"linger":200}}`
+	batchYaml := "stream:\n  type: console\nbatch:\n  enabled: true\n  maxCount: 50\n  maxBytes: 65536\n  linger: 200\n"
+	badCount := `{"stream":{"type":"console"},"batch":{"enabled":true,"maxCount":-1}}`
+	batching := `"batch":{"enabled":true,"maxCount":50,"maxBytes":65536,"linger":200}`
+	withOutbox := `{"stream":{"type":"console"},"outbox":{"enabled":true,"path":"/tmp/outbox"},` + batching + `}`
+	kafka := `{"stream":{"type":"kafka","config":{"provider":{"host":"localhost","port":9092},"topics":["alvarium"]}},` +
+		batching + `}`
+	jetStream := `{"streams":[{"type":"console"},{"type":"nats","config":{"provider":{"host":"localhost","port":4222},` +
+		`"subjects":["alvarium"],"jetStream":true}}],` + batching + `}`
+	coreNats := `{"stream":{"type":"nats","config":{"provider":{"host":"localhost","port":4222},"subjects":["alvarium"]}},` +
+		batching + `}`
+	mqttV5Yaml := "stream:\n  type: mqtt\n  config:\n    provider:\n      host: localhost\n      port: 1883\n" +
+		"    topics: [alvarium]\n    protocolVersion: 5\nbatch:\n  enabled: true\n  maxCount: 50\n  maxBytes: 65536\n  linger: 200\n"
+
+	tests := []struct {
+		name        string
+		data        string
+		yaml        bool
+		expectError bool
+	}{
+		{"valid batch", batch, false, false},
+		{"valid batch yaml", batchYaml, true, false},
+		{"invalid max count", badCount, false, true},
+		{"with outbox", withOutbox, false, true},
+		{"kafka", kafka, false, false},
+		{"nats jetstream member", jetStream, false, false},
+		{"core nats", coreNats, false, false},
+		{"mqtt 5 yaml", mqttV5Yaml, true, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var cfg SdkInfo
+			var err error
+			if tt.yaml {
+				err = yaml.Unmarshal([]byte(tt.data), &cfg)
+			} else {
+				err = json.Unmarshal([]byte(tt.data), &cfg)
+			}
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err == nil {
+				if !cfg.Batch.Enabled || cfg.Batch.MaxCount != 50 || cfg.Batch.MaxBytes != 65536 || cfg.Batch.Linger != 200 {
+					t.Errorf("unexpected batch config %v", cfg.Batch)
+				}
+			}
+		})
+	}
+}
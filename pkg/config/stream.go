@@ -16,6 +16,7 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
@@ -25,12 +26,14 @@ import (
 // StreamInfo facilitates configuration of a given streaming platform that will receive annotations
 type StreamInfo struct {
 	Type   contracts.StreamType `json:"type,omitempty" yaml:"type"`
+	Name   string               `json:"name,omitempty" yaml:"name"` // Name identifies the stream in errors and logs, optional
 	Config interface{}          `json:"config,omitempty" yaml:"config"`
 }
 
 func (s *StreamInfo) UnmarshalJSON(data []byte) (err error) {
 	type Alias struct {
 		Type contracts.StreamType `json:"type,omitempty"`
+		Name string               `json:"name,omitempty"`
 	}
 	a := Alias{}
 	// Error with unmarshaling
@@ -55,6 +58,58 @@ func (s *StreamInfo) UnmarshalJSON(data []byte) (err error) {
 		}
 		s.Type = m.Type
 		s.Config = m.Config
+	} else if a.Type == contracts.NatsStream {
+		type natsAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config NatsConfig           `json:"config,omitempty"`
+		}
+
+		n := natsAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &n); err != nil {
+			return err
+		}
+		s.Type = n.Type
+		s.Config = n.Config
+	} else if a.Type == contracts.AmqpStream {
+		type amqpAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config AmqpConfig           `json:"config,omitempty"`
+		}
+
+		m := amqpAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &m); err != nil {
+			return err
+		}
+		s.Type = m.Type
+		s.Config = m.Config
+	} else if a.Type == contracts.WebhookStream {
+		type webhookAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config WebhookConfig        `json:"config,omitempty"`
+		}
+
+		w := webhookAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &w); err != nil {
+			return err
+		}
+		s.Type = w.Type
+		s.Config = w.Config
+	} else if a.Type == contracts.FileStream {
+		type fileAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config FileConfig           `json:"config,omitempty"`
+		}
+
+		f := fileAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &f); err != nil {
+			return err
+		}
+		s.Type = f.Type
+		s.Config = f.Config
 	} else if a.Type == contracts.MockStream {
 		type mockAlias struct {
 			Type   contracts.StreamType `json:"type,omitempty"`
@@ -70,7 +125,7 @@ func (s *StreamInfo) UnmarshalJSON(data []byte) (err error) {
 	} else if a.Type == contracts.ConsoleStream {
 		type consoleAlias struct {
 			Type   contracts.StreamType `json:"type,omitempty"`
-			Config MockStreamConfig     `json:"config,omitempty"`
+			Config ConsoleConfig        `json:"config,omitempty"`
 		}
 		c := consoleAlias{}
 		// Error with unmarshaling
@@ -78,7 +133,7 @@ func (s *StreamInfo) UnmarshalJSON(data []byte) (err error) {
 			return err
 		}
 		s.Type = c.Type
-		s.Config = MockStreamConfig{}
+		s.Config = c.Config
 	} else if a.Type == contracts.HederaStream {
 		type hederaAlias struct {
 			Type   contracts.StreamType `json:"type,omitempty"`
@@ -92,16 +147,70 @@ func (s *StreamInfo) UnmarshalJSON(data []byte) (err error) {
 		}
 		s.Type = h.Type
 		s.Config = h.Config
+	} else if a.Type == contracts.PravegaStream {
+		type pravegaAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config PravegaConfig        `json:"config,omitempty"`
+		}
+
+		p := pravegaAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &p); err != nil {
+			return err
+		}
+		s.Type = p.Type
+		s.Config = p.Config
+	} else if a.Type == contracts.GrpcStream {
+		type grpcAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config GrpcConfig           `json:"config,omitempty"`
+		}
+
+		g := grpcAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &g); err != nil {
+			return err
+		}
+		s.Type = g.Type
+		s.Config = g.Config
+	} else if a.Type == contracts.RedisStream {
+		type redisAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config RedisConfig          `json:"config,omitempty"`
+		}
+
+		r := redisAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &r); err != nil {
+			return err
+		}
+		s.Type = r.Type
+		s.Config = r.Config
+	} else if a.Type == contracts.KafkaStream {
+		type kafkaAlias struct {
+			Type   contracts.StreamType `json:"type,omitempty"`
+			Config KafkaConfig          `json:"config,omitempty"`
+		}
+
+		k := kafkaAlias{}
+		// Error with unmarshaling
+		if err = json.Unmarshal(data, &k); err != nil {
+			return err
+		}
+		s.Type = k.Type
+		s.Config = k.Config
 	} else {
 		return fmt.Errorf("unhandled StreamInfo.Type value %s", a.Type)
 	}
 
+	s.Name = a.Name
 	return nil
 }
 
 func (s *StreamInfo) UnmarshalYAML(data *yaml.Node) (err error) {
 	type Alias struct {
 		Type contracts.StreamType `yaml:"type"`
+		Name string               `yaml:"name"`
 	}
 	a := Alias{}
 	// Error with unmarshaling
@@ -126,6 +235,58 @@ func (s *StreamInfo) UnmarshalYAML(data *yaml.Node) (err error) {
 		}
 		s.Type = m.Type
 		s.Config = m.Config
+	} else if a.Type == contracts.NatsStream {
+		type natsAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config NatsConfig           `yaml:"config"`
+		}
+
+		n := natsAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&n); err != nil {
+			return err
+		}
+		s.Type = n.Type
+		s.Config = n.Config
+	} else if a.Type == contracts.AmqpStream {
+		type amqpAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config AmqpConfig           `yaml:"config"`
+		}
+
+		m := amqpAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&m); err != nil {
+			return err
+		}
+		s.Type = m.Type
+		s.Config = m.Config
+	} else if a.Type == contracts.WebhookStream {
+		type webhookAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config WebhookConfig        `yaml:"config"`
+		}
+
+		w := webhookAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&w); err != nil {
+			return err
+		}
+		s.Type = w.Type
+		s.Config = w.Config
+	} else if a.Type == contracts.FileStream {
+		type fileAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config FileConfig           `yaml:"config"`
+		}
+
+		f := fileAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&f); err != nil {
+			return err
+		}
+		s.Type = f.Type
+		s.Config = f.Config
 	} else if a.Type == contracts.HederaStream {
 		type hederaAlias struct {
 			Type   contracts.StreamType `yaml:"type"`
@@ -153,8 +314,8 @@ func (s *StreamInfo) UnmarshalYAML(data *yaml.Node) (err error) {
 		s.Config = m.Config
 	} else if a.Type == contracts.ConsoleStream {
 		type consoleAlias struct {
-			Type   contracts.StreamType `json:"type,omitempty"`
-			Config MockStreamConfig     `json:"config,omitempty"`
+			Type   contracts.StreamType `yaml:"type"`
+			Config ConsoleConfig        `yaml:"config"`
 		}
 		c := consoleAlias{}
 		// Error with unmarshaling
@@ -162,28 +323,364 @@ func (s *StreamInfo) UnmarshalYAML(data *yaml.Node) (err error) {
 			return err
 		}
 		s.Type = c.Type
-		s.Config = MockStreamConfig{}
+		s.Config = c.Config
+	} else if a.Type == contracts.PravegaStream {
+		type pravegaAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config PravegaConfig        `yaml:"config"`
+		}
+
+		p := pravegaAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&p); err != nil {
+			return err
+		}
+		s.Type = p.Type
+		s.Config = p.Config
+	} else if a.Type == contracts.GrpcStream {
+		type grpcAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config GrpcConfig           `yaml:"config"`
+		}
+
+		g := grpcAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&g); err != nil {
+			return err
+		}
+		s.Type = g.Type
+		s.Config = g.Config
+	} else if a.Type == contracts.RedisStream {
+		type redisAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config RedisConfig          `yaml:"config"`
+		}
+
+		r := redisAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&r); err != nil {
+			return err
+		}
+		s.Type = r.Type
+		s.Config = r.Config
+	} else if a.Type == contracts.KafkaStream {
+		type kafkaAlias struct {
+			Type   contracts.StreamType `yaml:"type"`
+			Config KafkaConfig          `yaml:"config"`
+		}
+
+		k := kafkaAlias{}
+		// Error with unmarshaling
+		if err = data.Decode(&k); err != nil {
+			return err
+		}
+		s.Type = k.Type
+		s.Config = k.Config
 	} else {
 		return fmt.Errorf("unhandled StreamInfo.Type value %s", a.Type)
 	}
 
+	s.Name = a.Name
 	return nil
 }
 
 // MqttConfig exposes properties relevant to connecting to an existing MQTT broker
 type MqttConfig struct {
-	ClientId  string      `json:"clientId,omitempty" yaml:"clientId"`
-	Qos       int         `json:"qos,omitempty" yaml:"qos"`
-	User      string      `json:"user,omitempty" yaml:"user"`
-	Password  string      `json:"password,omitempty" yaml:"password"`
-	Provider  ServiceInfo `json:"provider,omitempty" yaml:"provider"`
-	Cleanness bool        `json:"cleanness,omitempty" yaml:"cleanness"`
-	Topics    []string    `json:"topics,omitempty" yaml:"topics"`
+	ClientId       string      `json:"clientId,omitempty" yaml:"clientId"`
+	Qos            int         `json:"qos,omitempty" yaml:"qos"`
+	User           string      `json:"user,omitempty" yaml:"user"`
+	Password       string      `json:"password,omitempty" yaml:"password"`
+	Provider       ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Cleanness      bool        `json:"cleanness,omitempty" yaml:"cleanness"`
+	Topics         []string    `json:"topics,omitempty" yaml:"topics"`
+	PublishTimeout int         `json:"publishTimeout,omitempty" yaml:"publishTimeout"` // Milliseconds to wait for a publish to complete, defaults to 2000
+	WaitOnClose    int         `json:"waitOnClose,omitempty" yaml:"waitOnClose"`       // Milliseconds allowed for in-flight work on disconnect, defaults to 250
+	Tls            TlsInfo     `json:"tls,omitempty" yaml:"tls"`
+	// ProtocolVersion selects MQTT 3.1 (3), 3.1.1 (4) or 5. When omitted the 3.1.1 client is used.
+	ProtocolVersion int `json:"protocolVersion,omitempty" yaml:"protocolVersion"`
+	// MessageExpiry is the lifetime of published messages in seconds, MQTT 5 only. Zero means no expiry.
+	MessageExpiry int `json:"messageExpiry,omitempty" yaml:"messageExpiry"`
+}
+
+// NatsConfig exposes properties relevant to connecting to an existing NATS server
+type NatsConfig struct {
+	Name           string      `json:"name,omitempty" yaml:"name"` // Name identifies the client connection to the server
+	Provider       ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Subjects       []string    `json:"subjects,omitempty" yaml:"subjects"`
+	JetStream      bool        `json:"jetStream,omitempty" yaml:"jetStream"` // JetStream publishes with acknowledgements and deduplication
+	User           string      `json:"user,omitempty" yaml:"user"`           // User and Password enable basic authentication
+	Password       string      `json:"password,omitempty" yaml:"password"`
+	Token          string      `json:"token,omitempty" yaml:"token"`                   // Token enables token authentication
+	CredsPath      string      `json:"credsPath,omitempty" yaml:"credsPath"`           // CredsPath is a user JWT/NKey credentials file
+	PublishTimeout int         `json:"publishTimeout,omitempty" yaml:"publishTimeout"` // Milliseconds to wait for the server to confirm a publish, defaults to 2000
+	WaitOnClose    int         `json:"waitOnClose,omitempty" yaml:"waitOnClose"`       // Milliseconds Close waits for buffered messages to be flushed, defaults to 5000
+	Tls            TlsInfo     `json:"tls,omitempty" yaml:"tls"`
+}
+
+// AmqpConfig exposes properties relevant to publishing to an existing AMQP 0-9-1 broker such as RabbitMQ
+type AmqpConfig struct {
+	Provider        ServiceInfo `json:"provider,omitempty" yaml:"provider"` // Protocol is "amqp" or "amqps", defaults to "amqps" when Tls is set and "amqp" otherwise
+	User            string      `json:"user,omitempty" yaml:"user"`
+	Password        string      `json:"password,omitempty" yaml:"password"`
+	VHost           string      `json:"vhost,omitempty" yaml:"vhost"`
+	Exchange        string      `json:"exchange,omitempty" yaml:"exchange"`
+	ExchangeType    string      `json:"exchangeType,omitempty" yaml:"exchangeType"`       // Used when declaring the exchange, defaults to "topic"
+	DeclareExchange bool        `json:"declareExchange,omitempty" yaml:"declareExchange"` // Declares a durable exchange on connect
+	RoutingKeys     []string    `json:"routingKeys,omitempty" yaml:"routingKeys"`         // Each message is published once per routing key
+	Mandatory       bool        `json:"mandatory,omitempty" yaml:"mandatory"`             // Treat messages the broker cannot route as failures
+	Persistent      bool        `json:"persistent,omitempty" yaml:"persistent"`           // Ask the broker to persist messages to disk
+	ConfirmTimeout  int         `json:"confirmTimeout,omitempty" yaml:"confirmTimeout"`   // Milliseconds to wait for a publisher confirm, defaults to 5000
+	Tls             TlsInfo     `json:"tls,omitempty" yaml:"tls"`
+}
+
+// WebhookConfig describes HTTP endpoints that receive each PublishWrapper as the body of a POST request.
+type WebhookConfig struct {
+	Urls             []string          `json:"urls,omitempty" yaml:"urls"`                         // Each message is delivered to every URL
+	Headers          map[string]string `json:"headers,omitempty" yaml:"headers"`                   // Added to every request, e.g. Authorization
+	Timeout          int               `json:"timeout,omitempty" yaml:"timeout"`                   // Milliseconds allowed per request, defaults to 5000
+	MaxRetries       int               `json:"maxRetries,omitempty" yaml:"maxRetries"`             // Retries after the first attempt, defaults to 3. Use -1 to disable.
+	RetryInterval    int               `json:"retryInterval,omitempty" yaml:"retryInterval"`       // Milliseconds before the first retry, doubled on each attempt. Defaults to 500
+	MaxRetryInterval int               `json:"maxRetryInterval,omitempty" yaml:"maxRetryInterval"` // Upper bound of the backoff in milliseconds, defaults to 30000
+	Signature        *SignatureInfo    `json:"signature,omitempty" yaml:"signature"`               // When set, requests carry HTTP message signature headers
+	SignatureFields  []string          `json:"signatureFields,omitempty" yaml:"signatureFields"`   // Components covered by the signature
+	Tls              TlsInfo           `json:"tls,omitempty" yaml:"tls"`
+}
+
+// GrpcConfig exposes properties relevant to publishing to an annotation collector over gRPC
+type GrpcConfig struct {
+	Provider       ServiceInfo       `json:"provider,omitempty" yaml:"provider"`
+	Metadata       map[string]string `json:"metadata,omitempty" yaml:"metadata"`             // Sent with each stream, e.g. an authorization token
+	ConnectTimeout int               `json:"connectTimeout,omitempty" yaml:"connectTimeout"` // Milliseconds to wait for the collector connection, defaults to 5000
+	AckTimeout     int               `json:"ackTimeout,omitempty" yaml:"ackTimeout"`         // Milliseconds to wait for a message to be acknowledged, defaults to 5000
+	Tls            TlsInfo           `json:"tls,omitempty" yaml:"tls"`
+}
+
+func (g *GrpcConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias GrpcConfig
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateGrpc(GrpcConfig(a)); err != nil {
+		return err
+	}
+	*g = GrpcConfig(a)
+	return nil
+}
+
+func (g *GrpcConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias GrpcConfig
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateGrpc(GrpcConfig(a)); err != nil {
+		return err
+	}
+	*g = GrpcConfig(a)
+	return nil
+}
+
+func validateGrpc(g GrpcConfig) error {
+	if g.ConnectTimeout < 0 || g.AckTimeout < 0 {
+		return fmt.Errorf("invalid GrpcConfig values provided connectTimeout %d ackTimeout %d", g.ConnectTimeout, g.AckTimeout)
+	}
+	return nil
+}
+
+// RedisConfig exposes properties relevant to appending to Redis Streams
+type RedisConfig struct {
+	Provider       ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Streams        []string    `json:"streams,omitempty" yaml:"streams"`   // Each message is added to every stream
+	MaxLen         int64       `json:"maxLen,omitempty" yaml:"maxLen"`     // Trims each stream to about this many entries, zero disables
+	Db             int         `json:"db,omitempty" yaml:"db"`             // Database selected after connecting
+	Username       string      `json:"username,omitempty" yaml:"username"` // Username and Password enable authentication, Username defaults to the default user
+	Password       string      `json:"password,omitempty" yaml:"password"`
+	PublishTimeout int         `json:"publishTimeout,omitempty" yaml:"publishTimeout"` // Milliseconds to wait for each command, defaults to 2000
+	Group          string      `json:"group,omitempty" yaml:"group"`                   // Subscriber only, consumer group created if missing, defaults to "alvarium"
+	Consumer       string      `json:"consumer,omitempty" yaml:"consumer"`             // Subscriber only, name within the group, defaults to the hostname
+	ClaimIdle      int         `json:"claimIdle,omitempty" yaml:"claimIdle"`           // Subscriber only, milliseconds before unacknowledged messages are redelivered, defaults to 30000
+	Tls            TlsInfo     `json:"tls,omitempty" yaml:"tls"`
+}
+
+func (r *RedisConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias RedisConfig
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateRedis(RedisConfig(a)); err != nil {
+		return err
+	}
+	*r = RedisConfig(a)
+	return nil
+}
+
+func (r *RedisConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias RedisConfig
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateRedis(RedisConfig(a)); err != nil {
+		return err
+	}
+	*r = RedisConfig(a)
+	return nil
+}
+
+func validateRedis(r RedisConfig) error {
+	if r.MaxLen < 0 || r.Db < 0 || r.PublishTimeout < 0 || r.ClaimIdle < 0 {
+		return fmt.Errorf("invalid RedisConfig values provided maxLen %d db %d publishTimeout %d claimIdle %d",
+			r.MaxLen, r.Db, r.PublishTimeout, r.ClaimIdle)
+	}
+	return nil
+}
+
+// FileConfig describes a local file receiving one JSON encoded PublishWrapper per line. Rotated segments are kept
+// next to the active file, named after it with the rotation time inserted before the extension.
+type FileConfig struct {
+	Path           string                `json:"path,omitempty" yaml:"path"`                     // Active file, e.g. /var/lib/alvarium/annotations.jsonl
+	MaxSize        int64                 `json:"maxSize,omitempty" yaml:"maxSize"`               // Rotate once the file would exceed this many bytes, zero disables
+	RotateInterval int                   `json:"rotateInterval,omitempty" yaml:"rotateInterval"` // Rotate after this many milliseconds, zero disables
+	MaxBackups     int                   `json:"maxBackups,omitempty" yaml:"maxBackups"`         // Number of rotated segments to retain, zero keeps all
+	Compress       bool                  `json:"compress,omitempty" yaml:"compress"`             // Gzip rotated segments
+	Fsync          contracts.FsyncPolicy `json:"fsync,omitempty" yaml:"fsync"`                   // Defaults to "never"
+	FsyncInterval  int                   `json:"fsyncInterval,omitempty" yaml:"fsyncInterval"`   // Milliseconds between syncs for the "interval" policy, defaults to 1000
+	OffsetPath     string                `json:"offsetPath,omitempty" yaml:"offsetPath"`         // Subscriber only, records the acknowledged position so that reading resumes there
+	PollInterval   int                   `json:"pollInterval,omitempty" yaml:"pollInterval"`     // Subscriber only, milliseconds between checks for new lines, defaults to 500
+}
+
+func (f *FileConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias FileConfig
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateFile(FileConfig(a)); err != nil {
+		return err
+	}
+	*f = FileConfig(a)
+	return nil
+}
+
+func (f *FileConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias FileConfig
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateFile(FileConfig(a)); err != nil {
+		return err
+	}
+	*f = FileConfig(a)
+	return nil
+}
+
+func validateFile(f FileConfig) error {
+	if f.Fsync != "" && !f.Fsync.Validate() {
+		return fmt.Errorf("invalid FsyncPolicy value provided %s", f.Fsync)
+	}
+	if f.MaxSize < 0 || f.RotateInterval < 0 || f.MaxBackups < 0 || f.FsyncInterval < 0 || f.PollInterval < 0 {
+		return fmt.Errorf("invalid FileConfig values provided maxSize %d rotateInterval %d maxBackups %d fsyncInterval %d pollInterval %d",
+			f.MaxSize, f.RotateInterval, f.MaxBackups, f.FsyncInterval, f.PollInterval)
+	}
+	return nil
+}
+
+// ConsoleConfig selects how the console stream prints messages
+type ConsoleConfig struct {
+	Format            contracts.ConsoleFormat `json:"format,omitempty" yaml:"format"`                       // One of "text", "table", "json" or "ndjson", defaults to "text"
+	Output            contracts.ConsoleOutput `json:"output,omitempty" yaml:"output"`                       // One of "stdout" or "stderr", defaults to "stdout"
+	ExpandAnnotations bool                    `json:"expandAnnotations,omitempty" yaml:"expandAnnotations"` // Print each annotation of an AnnotationList rather than the raw content
+	PublicKey         *KeyInfo                `json:"publicKey,omitempty" yaml:"publicKey"`                 // Verifies the signature of expanded annotations
+}
+
+func (c *ConsoleConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias ConsoleConfig
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateConsole(ConsoleConfig(a)); err != nil {
+		return err
+	}
+	*c = ConsoleConfig(a)
+	return nil
+}
+
+func (c *ConsoleConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias ConsoleConfig
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateConsole(ConsoleConfig(a)); err != nil {
+		return err
+	}
+	*c = ConsoleConfig(a)
+	return nil
+}
+
+func validateConsole(c ConsoleConfig) error {
+	if c.Format != "" && !c.Format.Validate() {
+		return fmt.Errorf("invalid ConsoleFormat value provided %s", c.Format)
+	}
+	if c.Output != "" && !c.Output.Validate() {
+		return fmt.Errorf("invalid ConsoleOutput value provided %s", c.Output)
+	}
+	return nil
 }
 
 // MockStreamConfig exposes properties to simulate a stream connection for testing.
 type MockStreamConfig struct {
-	Provider ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Provider    ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Latency     int         `json:"latency,omitempty" yaml:"latency"`         // Milliseconds each publish takes
+	FailEvery   int         `json:"failEvery,omitempty" yaml:"failEvery"`     // Every nth publish fails, zero disables
+	FailConnect bool        `json:"failConnect,omitempty" yaml:"failConnect"` // Connect fails, simulating an unreachable stream
+}
+
+func (m *MockStreamConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias MockStreamConfig
+	a := Alias{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	if err = validateMock(MockStreamConfig(a)); err != nil {
+		return err
+	}
+	*m = MockStreamConfig(a)
+	return nil
+}
+
+func (m *MockStreamConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias MockStreamConfig
+	a := Alias{}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	if err = validateMock(MockStreamConfig(a)); err != nil {
+		return err
+	}
+	*m = MockStreamConfig(a)
+	return nil
+}
+
+func validateMock(m MockStreamConfig) error {
+	if m.Latency < 0 || m.FailEvery < 0 {
+		return fmt.Errorf("invalid MockStreamConfig values provided latency %d failEvery %d", m.Latency, m.FailEvery)
+	}
+	return nil
 }
 
 // HederaConfig provides configuartion required to init a Hedera client and connect to the consensus nodes
@@ -198,9 +695,188 @@ type HederaConfig struct {
 	DefaultMaxTxFee        float64           `json:"defaultMaxTxFee,omitempty" yaml:"defaultMaxTxFee"`
 	DefaultMaxQueryPayment float64           `json:"defaultMaxQueryPayment,omitempty" yaml:"defaultMaxQueryPayment"`
 	ShouldBroadcastTopic   bool              `json:"shouldBroadcastTopic,omitempty" yaml:"shouldBroadcastTopic"`
+	MaxChunks              int               `json:"maxChunks,omitempty" yaml:"maxChunks"`           // Most chunks the SDK may split a single publish into, defaults to 20
+	Compress               bool              `json:"compress,omitempty" yaml:"compress"`             // Gzip the PublishWrapper before submitting it
+	WaitForReceipt         bool              `json:"waitForReceipt,omitempty" yaml:"waitForReceipt"` // Wait for consensus on each message and log its proof
+	FetchRecord            bool              `json:"fetchRecord,omitempty" yaml:"fetchRecord"`       // Also query the transaction record, which carries the consensus timestamp
+	OffsetPath             string            `json:"offsetPath,omitempty" yaml:"offsetPath"`         // Subscriber only, records the last acknowledged sequence number of each topic
+	CreateTopics           bool              `json:"createTopics,omitempty" yaml:"createTopics"`     // Create topics at Connect until TopicCount exist, replacing deleted ones
+	TopicCount             int               `json:"topicCount,omitempty" yaml:"topicCount"`         // Number of topics to publish to when creating topics, defaults to 1
+	TopicMemo              string            `json:"topicMemo,omitempty" yaml:"topicMemo"`           // Memo of created topics
+	TopicsPath             string            `json:"topicsPath,omitempty" yaml:"topicsPath"`         // Records created topics so that they are reused after a restart
+	SubmitKeyPath          string            `json:"submitKeyPath,omitempty" yaml:"submitKeyPath"`   // Private key signing submitted messages, created topics require its signature
+
+	// BroadcastStream announces the topics in use. For compatibility, an MqttConfig without a type is also accepted.
+	BroadcastStream StreamInfo   `json:"broadcastStream,omitempty" yaml:"broadcastStream"`
+	Budget          HederaBudget `json:"budget,omitempty" yaml:"budget"`
+}
+
+// HederaBudget caps the HBAR spent on transaction fees by the Hedera publisher. Spend is tracked in clock-aligned
+// UTC hours and days.
+type HederaBudget struct {
+	HourlyLimit    float64                `json:"hourlyLimit,omitempty" yaml:"hourlyLimit"`       // HBAR that may be spent per hour, zero disables
+	DailyLimit     float64                `json:"dailyLimit,omitempty" yaml:"dailyLimit"`         // HBAR that may be spent per day, zero disables
+	OnExhausted    contracts.BudgetPolicy `json:"onExhausted,omitempty" yaml:"onExhausted"`       // Defaults to "pause"
+	FallbackStream *StreamInfo            `json:"fallbackStream,omitempty" yaml:"fallbackStream"` // Receives messages while exhausted under the "fallback" policy
+	StatePath      string                 `json:"statePath,omitempty" yaml:"statePath"`           // Records the spend so that limits hold across restarts
+}
+
+// Enabled reports whether any limit is set
+func (b HederaBudget) Enabled() bool {
+	return b.HourlyLimit > 0 || b.DailyLimit > 0
+}
+
+// HederaMaxChunkSize is the largest message accepted by the Hedera consensus service and the size of the chunks
+// larger messages are split into by the SDK
+const HederaMaxChunkSize = 1024
+
+func (h *HederaConfig) UnmarshalJSON(data []byte) (err error) {
+	type Alias HederaConfig
+	a := struct {
+		Alias
+		BroadcastStream json.RawMessage `json:"broadcastStream,omitempty"`
+	}{}
+	if err = json.Unmarshal(data, &a); err != nil {
+		return err
+	}
+
+	cfg := HederaConfig(a.Alias)
+	var fields map[string]json.RawMessage
+	if len(a.BroadcastStream) > 0 {
+		if err = json.Unmarshal(a.BroadcastStream, &fields); err != nil {
+			return err
+		}
+	}
+	if len(fields) > 0 {
+		var probe struct {
+			Type contracts.StreamType `json:"type,omitempty"`
+		}
+		if err = json.Unmarshal(a.BroadcastStream, &probe); err != nil {
+			return err
+		}
+		if probe.Type == "" {
+			var m MqttConfig
+			if err = json.Unmarshal(a.BroadcastStream, &m); err != nil {
+				return err
+			}
+			cfg.BroadcastStream = StreamInfo{Type: contracts.MqttStream, Config: m}
+		} else if err = json.Unmarshal(a.BroadcastStream, &cfg.BroadcastStream); err != nil {
+			return err
+		}
+	}
+
+	if err = validateHedera(cfg); err != nil {
+		return err
+	}
+	*h = cfg
+	return nil
+}
+
+func (h *HederaConfig) UnmarshalYAML(data *yaml.Node) (err error) {
+	type Alias HederaConfig
+	var a struct {
+		BroadcastStream yaml.Node `yaml:"broadcastStream"`
+	}
+	if err = data.Decode(&a); err != nil {
+		return err
+	}
+
+	// Decode the remaining fields without broadcastStream, which may be in the legacy form
+	rest := *data
+	rest.Content = nil
+	for i := 0; i+1 < len(data.Content); i += 2 {
+		if data.Content[i].Value != "broadcastStream" {
+			rest.Content = append(rest.Content, data.Content[i], data.Content[i+1])
+		}
+	}
+	var alias Alias
+	if err = rest.Decode(&alias); err != nil {
+		return err
+	}
+
+	cfg := HederaConfig(alias)
+	if len(a.BroadcastStream.Content) > 0 {
+		var probe struct {
+			Type contracts.StreamType `yaml:"type"`
+		}
+		if err = a.BroadcastStream.Decode(&probe); err != nil {
+			return err
+		}
+		if probe.Type == "" {
+			var m MqttConfig
+			if err = a.BroadcastStream.Decode(&m); err != nil {
+				return err
+			}
+			cfg.BroadcastStream = StreamInfo{Type: contracts.MqttStream, Config: m}
+		} else if err = a.BroadcastStream.Decode(&cfg.BroadcastStream); err != nil {
+			return err
+		}
+	}
+
+	if err = validateHedera(cfg); err != nil {
+		return err
+	}
+	*h = cfg
+	return nil
+}
+
+func validateHedera(h HederaConfig) error {
+	if h.MaxChunks < 0 || h.TopicCount < 0 {
+		return fmt.Errorf("invalid HederaConfig values provided maxChunks %d topicCount %d", h.MaxChunks, h.TopicCount)
+	}
+	if h.ShouldBroadcastTopic && h.BroadcastStream.Type == "" {
+		return errors.New("broadcastStream must be provided when shouldBroadcastTopic is set")
+	}
+	if h.Budget.HourlyLimit < 0 || h.Budget.DailyLimit < 0 {
+		return fmt.Errorf("invalid HederaBudget values provided hourlyLimit %f dailyLimit %f",
+			h.Budget.HourlyLimit, h.Budget.DailyLimit)
+	}
+	if h.Budget.OnExhausted != "" && !h.Budget.OnExhausted.Validate() {
+		return fmt.Errorf("invalid BudgetPolicy value provided %s", h.Budget.OnExhausted)
+	}
+	if h.Budget.OnExhausted == contracts.BudgetFallback && h.Budget.FallbackStream == nil {
+		return errors.New("budget fallbackStream must be provided for the fallback policy")
+	}
+	return nil
+}
+
+const (
+	KafkaAcksNone   = "none"   // Do not wait for the broker to acknowledge the write
+	KafkaAcksLeader = "leader" // Wait for the partition leader to acknowledge the write
+	KafkaAcksAll    = "all"    // Wait for all in-sync replicas to acknowledge the write
+)
+
+// KafkaConfig exposes properties relevant to producing to an existing Kafka cluster
+type KafkaConfig struct {
+	ClientId    string   `json:"clientId,omitempty" yaml:"clientId"`
+	Brokers     []string `json:"brokers,omitempty" yaml:"brokers"` // Brokers is a list of host:port bootstrap addresses
+	Topics      []string `json:"topics,omitempty" yaml:"topics"`
+	Version     string   `json:"version,omitempty" yaml:"version"`         // Version is the Kafka protocol version, e.g. "2.8.0"
+	Acks        string   `json:"acks,omitempty" yaml:"acks"`               // Acks is one of "none", "leader" or "all", defaults to "all"
+	Compression string   `json:"compression,omitempty" yaml:"compression"` // Compression is one of "none", "gzip", "snappy", "lz4" or "zstd"
+	Idempotent  bool     `json:"idempotent,omitempty" yaml:"idempotent"`   // Idempotent enables the idempotent producer, requires acks "all"
+	Sasl        SaslInfo `json:"sasl,omitempty" yaml:"sasl"`
+	Tls         TlsInfo  `json:"tls,omitempty" yaml:"tls"`
+}
+
+// SaslInfo holds SASL credentials. An empty Mechanism disables SASL authentication.
+type SaslInfo struct {
+	Mechanism string `json:"mechanism,omitempty" yaml:"mechanism"` // One of "PLAIN", "SCRAM-SHA-256" or "SCRAM-SHA-512"
+	User      string `json:"user,omitempty" yaml:"user"`
+	Password  string `json:"password,omitempty" yaml:"password"`
+}
 
-	// TODO (Ali Amin): Add support for other providers
-	BroadcastStream MqttConfig `json:"broadcastStream,omitempty" yaml:"broadcastStream"`
+// PravegaConfig exposes properties relevant to writing events to a Pravega stream. Pravega has no native Go client,
+// so Provider refers to a Pravega gRPC gateway fronting the cluster.
+type PravegaConfig struct {
+	Provider       ServiceInfo `json:"provider,omitempty" yaml:"provider"`
+	Scope          string      `json:"scope,omitempty" yaml:"scope"`
+	Stream         string      `json:"stream,omitempty" yaml:"stream"`
+	CreateStream   bool        `json:"createStream,omitempty" yaml:"createStream"`     // CreateStream creates the scope and stream on Connect if missing
+	MinSegments    int         `json:"minSegments,omitempty" yaml:"minSegments"`       // MinSegments applies to a stream created on Connect, defaults to 1
+	ConnectTimeout int         `json:"connectTimeout,omitempty" yaml:"connectTimeout"` // Milliseconds to wait for the gateway connection, defaults to 5000
+	WriteTimeout   int         `json:"writeTimeout,omitempty" yaml:"writeTimeout"`     // Milliseconds to wait for an event to be acknowledged, defaults to 5000
+	Tls            TlsInfo     `json:"tls,omitempty" yaml:"tls"`
 }
 
 // ServiceInfo describes a service endpoint that the deployed service is a client of. Right now, this is implicitly
@@ -19,6 +19,7 @@ import (
 
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
 	"github.com/project-alvarium/alvarium-sdk-go/test"
+	"gopkg.in/yaml.v3"
 )
 
 func TestStreamInfoUnmarshal(t *testing.T) {
@@ -84,14 +85,170 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 		Config: localHedera,
 	}
 
+	streamKafka := KafkaConfig{
+		Brokers: []string{"localhost:9092"},
+		Topics:  []string{"alvarium"},
+		Acks:    KafkaAcksAll,
+		Sasl:    SaslInfo{Mechanism: "PLAIN", User: "user", Password: "password"},
+		Tls:     TlsInfo{Enabled: true},
+	}
+
+	pass6 := StreamInfo{
+		Type:   contracts.KafkaStream,
+		Config: streamKafka,
+	}
+
+	streamNats := NatsConfig{
+		Provider:  ServiceInfo{Host: "localhost", Port: 4222, Protocol: "nats"},
+		Subjects:  []string{"alvarium.annotations"},
+		JetStream: true,
+	}
+
+	pass8 := StreamInfo{
+		Type:   contracts.NatsStream,
+		Config: streamNats,
+	}
+
+	streamAmqp := AmqpConfig{
+		Provider:    ServiceInfo{Host: "localhost", Port: 5671, Protocol: "amqps"},
+		Exchange:    "alvarium",
+		RoutingKeys: []string{"annotations"},
+		Mandatory:   true,
+	}
+
+	pass9 := StreamInfo{
+		Type:   contracts.AmqpStream,
+		Config: streamAmqp,
+	}
+
+	streamWebhook := WebhookConfig{
+		Urls:    []string{"https://collector.example.com/annotations"},
+		Headers: map[string]string{"Authorization": "Bearer token"},
+		Signature: &SignatureInfo{
+			PublicKey:  KeyInfo{Type: contracts.KeyEd25519, Path: "./public.key"},
+			PrivateKey: KeyInfo{Type: contracts.KeyEd25519, Path: "./private.key"},
+		},
+	}
+
+	pass10 := StreamInfo{
+		Type:   contracts.WebhookStream,
+		Config: streamWebhook,
+	}
+
+	streamFile := FileConfig{
+		Path:     "/var/lib/alvarium/annotations.jsonl",
+		MaxSize:  1048576,
+		Compress: true,
+		Fsync:    contracts.FsyncInterval,
+	}
+
+	pass11 := StreamInfo{
+		Type:   contracts.FileStream,
+		Config: streamFile,
+	}
+
+	streamFileInvalid := streamFile
+	streamFileInvalid.Fsync = "invalid"
+
+	fail2 := StreamInfo{
+		Type:   contracts.FileStream,
+		Config: streamFileInvalid,
+	}
+
+	hederaInvalid := streamHedera
+	hederaInvalid.MaxChunks = -1
+
+	fail3 := StreamInfo{
+		Type:   contracts.HederaStream,
+		Config: hederaInvalid,
+	}
+
+	hederaBudgetPolicy := streamHedera
+	hederaBudgetPolicy.Budget = HederaBudget{HourlyLimit: 1, OnExhausted: "invalid"}
+
+	fail4 := StreamInfo{
+		Type:   contracts.HederaStream,
+		Config: hederaBudgetPolicy,
+	}
+
+	hederaBudgetFallback := streamHedera
+	hederaBudgetFallback.Budget = HederaBudget{DailyLimit: 10, OnExhausted: contracts.BudgetFallback}
+
+	fail5 := StreamInfo{
+		Type:   contracts.HederaStream,
+		Config: hederaBudgetFallback,
+	}
+
+	hederaBudget := streamHedera
+	hederaBudget.Budget = HederaBudget{
+		DailyLimit:     10,
+		OnExhausted:    contracts.BudgetFallback,
+		FallbackStream: &StreamInfo{Type: contracts.MqttStream, Config: streamMqtt},
+	}
+
+	pass12 := StreamInfo{
+		Type:   contracts.HederaStream,
+		Config: hederaBudget,
+	}
+
+	fail6 := StreamInfo{
+		Type:   contracts.MockStream,
+		Config: MockStreamConfig{FailEvery: -1},
+	}
+
+	fail7 := StreamInfo{
+		Type:   contracts.ConsoleStream,
+		Config: ConsoleConfig{Format: "xml"},
+	}
+
+	pass13 := StreamInfo{
+		Type:   contracts.ConsoleStream,
+		Config: ConsoleConfig{Format: contracts.ConsoleNdjson, Output: contracts.ConsoleStderr, ExpandAnnotations: true},
+	}
+
+	fail8 := StreamInfo{
+		Type:   contracts.RedisStream,
+		Config: RedisConfig{Provider: ServiceInfo{Host: "localhost", Port: 6379}, Streams: []string{"alvarium"}, MaxLen: -1},
+	}
+
+	pass14 := StreamInfo{
+		Type: contracts.RedisStream,
+		Config: RedisConfig{
+			Provider: ServiceInfo{Host: "localhost", Port: 6379},
+			Streams:  []string{"alvarium"},
+			MaxLen:   10000,
+			Group:    "verifiers",
+		},
+	}
+
+	fail9 := StreamInfo{
+		Type:   contracts.GrpcStream,
+		Config: GrpcConfig{Provider: ServiceInfo{Host: "collector", Port: 50051}, AckTimeout: -1},
+	}
+
+	pass15 := StreamInfo{
+		Type: contracts.GrpcStream,
+		Config: GrpcConfig{
+			Provider:   ServiceInfo{Host: "collector", Port: 50051},
+			Metadata:   map[string]string{"authorization": "Bearer token"},
+			AckTimeout: 10000,
+		},
+	}
+
 	fail := StreamInfo{
 		Type:   "invalid",
 		Config: streamMock,
 	}
 
-	fail2 := StreamInfo{
+	streamPravega := PravegaConfig{
+		Provider: ServiceInfo{Host: "localhost", Port: 54672},
+		Scope:    "alvarium",
+		Stream:   "annotations",
+	}
+
+	pass7 := StreamInfo{
 		Type:   contracts.PravegaStream,
-		Config: streamMock,
+		Config: streamPravega,
 	}
 
 	a, _ := json.Marshal(&pass)
@@ -100,7 +257,24 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 	d, _ := json.Marshal(&pass4)
 	e, _ := json.Marshal(&pass5)
 	f, _ := json.Marshal(&fail)
-	g, _ := json.Marshal(&fail2)
+	g, _ := json.Marshal(&pass7)
+	h, _ := json.Marshal(&pass6)
+	i, _ := json.Marshal(&pass8)
+	j, _ := json.Marshal(&pass9)
+	k, _ := json.Marshal(&pass10)
+	l, _ := json.Marshal(&pass11)
+	m, _ := json.Marshal(&fail2)
+	n, _ := json.Marshal(&fail3)
+	o, _ := json.Marshal(&fail4)
+	q, _ := json.Marshal(&fail5)
+	r, _ := json.Marshal(&pass12)
+	u, _ := json.Marshal(&fail6)
+	v, _ := json.Marshal(&fail7)
+	w, _ := json.Marshal(&pass13)
+	x, _ := json.Marshal(&fail8)
+	y, _ := json.Marshal(&pass14)
+	z, _ := json.Marshal(&fail9)
+	aa, _ := json.Marshal(&pass15)
 
 	tests := []struct {
 		name        string
@@ -112,8 +286,25 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 		{"valid StreamInfo type #3", c, false},
 		{"valid StreamInfo type #4", d, false},
 		{"valid StreamInfo type #5", e, false},
+		{"valid StreamInfo type #6", h, false},
 		{"invalid StreamInfo type", f, true},
-		{"unhandled StreamInfo type", g, true},
+		{"valid StreamInfo type #7", g, false},
+		{"valid StreamInfo type #8", i, false},
+		{"valid StreamInfo type #9", j, false},
+		{"valid StreamInfo type #10", k, false},
+		{"valid StreamInfo type #11", l, false},
+		{"invalid file fsync policy", m, true},
+		{"invalid hedera chunk size", n, true},
+		{"invalid hedera budget policy", o, true},
+		{"invalid hedera budget missing fallback", q, true},
+		{"valid hedera budget", r, false},
+		{"invalid mock fail every", u, true},
+		{"invalid console format", v, true},
+		{"valid console config", w, false},
+		{"invalid redis max length", x, true},
+		{"valid redis config", y, false},
+		{"invalid grpc ack timeout", z, true},
+		{"valid grpc config", aa, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -131,6 +322,11 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 					if cfg.AccountId != "testID" {
 						t.Errorf("unexpected account ID value %s", cfg.AccountId)
 					}
+					if cfg.Budget.FallbackStream != nil {
+						if _, ok := cfg.Budget.FallbackStream.Config.(MqttConfig); !ok {
+							t.Errorf("unexpected fallback stream config %v", cfg.Budget.FallbackStream.Config)
+						}
+					}
 					if cfg.NetType == contracts.Local {
 						if cfg.Consensus.Address() != "127.0.0.1:50211" {
 							t.Errorf("unexpected Consensus address %s", cfg.Consensus.Address())
@@ -139,6 +335,49 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 							t.Errorf("unexpected Mirror address %s", cfg.Mirror.Address())
 						}
 					}
+				} else if s.Type == contracts.KafkaStream {
+					cfg := s.Config.(KafkaConfig)
+					if len(cfg.Brokers) != 1 || cfg.Sasl.User != "user" || !cfg.Tls.Enabled {
+						t.Errorf("unexpected kafka config value %v", cfg)
+					}
+				} else if s.Type == contracts.PravegaStream {
+					cfg := s.Config.(PravegaConfig)
+					if cfg.Provider.Address() != "localhost:54672" || cfg.Scope != "alvarium" || cfg.Stream != "annotations" {
+						t.Errorf("unexpected pravega config value %v", cfg)
+					}
+				} else if s.Type == contracts.NatsStream {
+					cfg := s.Config.(NatsConfig)
+					if cfg.Provider.Uri() != "nats://localhost:4222" || !cfg.JetStream {
+						t.Errorf("unexpected nats config value %v", cfg)
+					}
+				} else if s.Type == contracts.AmqpStream {
+					cfg := s.Config.(AmqpConfig)
+					if cfg.Provider.Uri() != "amqps://localhost:5671" || cfg.Exchange != "alvarium" || !cfg.Mandatory {
+						t.Errorf("unexpected amqp config value %v", cfg)
+					}
+				} else if s.Type == contracts.WebhookStream {
+					cfg := s.Config.(WebhookConfig)
+					if len(cfg.Urls) != 1 || cfg.Headers["Authorization"] != "Bearer token" || cfg.Signature == nil ||
+						cfg.Signature.PrivateKey.Type != contracts.KeyEd25519 {
+						t.Errorf("unexpected webhook config value %v", cfg)
+					}
+				} else if s.Type == contracts.FileStream {
+					cfg := s.Config.(FileConfig)
+					if cfg.Path != "/var/lib/alvarium/annotations.jsonl" || cfg.MaxSize != 1048576 || !cfg.Compress ||
+						cfg.Fsync != contracts.FsyncInterval {
+						t.Errorf("unexpected file config value %v", cfg)
+					}
+				} else if s.Type == contracts.GrpcStream {
+					cfg := s.Config.(GrpcConfig)
+					if cfg.Provider.Address() != "collector:50051" || cfg.Metadata["authorization"] != "Bearer token" ||
+						cfg.AckTimeout != 10000 {
+						t.Errorf("unexpected grpc config value %v", cfg)
+					}
+				} else if s.Type == contracts.RedisStream {
+					cfg := s.Config.(RedisConfig)
+					if cfg.Provider.Address() != "localhost:6379" || cfg.MaxLen != 10000 || cfg.Group != "verifiers" {
+						t.Errorf("unexpected redis config value %v", cfg)
+					}
 				} else if s.Type == contracts.MockStream {
 					cfg := s.Config.(MockStreamConfig)
 					if cfg.Provider.Uri() != "http://localhost:8080" {
@@ -149,3 +388,68 @@ func TestStreamInfoUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestHederaBroadcastStreamUnmarshal(t *testing.T) {
+	tests := []struct {
+		name        string
+		json        string
+		yaml        string
+		expectType  contracts.StreamType
+		expectError bool
+	}{
+		{"no broadcast",
+			`{"topics":["0.0.2001"]}`,
+			"topics: [\"0.0.2001\"]",
+			"", false},
+		{"empty broadcast",
+			`{"topics":["0.0.2001"],"broadcastStream":{}}`,
+			"topics: [\"0.0.2001\"]\nbroadcastStream: {}",
+			"", false},
+		{"legacy mqtt broadcast",
+			`{"shouldBroadcastTopic":true,"broadcastStream":{"clientId":"sdk-test","provider":{"host":"localhost","protocol":"tcp","port":1883}}}`,
+			"shouldBroadcastTopic: true\nbroadcastStream:\n  clientId: sdk-test\n  provider: {host: localhost, protocol: tcp, port: 1883}",
+			contracts.MqttStream, false},
+		{"typed broadcast",
+			`{"shouldBroadcastTopic":true,"broadcastStream":{"type":"nats","config":{"provider":{"host":"localhost","protocol":"nats","port":4222},"subjects":["topics"]}}}`,
+			"shouldBroadcastTopic: true\nbroadcastStream:\n  type: nats\n  config:\n    provider: {host: localhost, protocol: nats, port: 4222}\n    subjects: [topics]",
+			contracts.NatsStream, false},
+		{"missing broadcast",
+			`{"shouldBroadcastTopic":true}`,
+			"shouldBroadcastTopic: true",
+			"", true},
+		{"invalid topic count",
+			`{"createTopics":true,"topicCount":-1}`,
+			"createTopics: true\ntopicCount: -1",
+			"", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromJson, fromYaml HederaConfig
+			err := json.Unmarshal([]byte(tt.json), &fromJson)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			yamlErr := yaml.Unmarshal([]byte(tt.yaml), &fromYaml)
+			test.CheckError(yamlErr, tt.expectError, tt.name, t)
+			if err != nil || yamlErr != nil {
+				return
+			}
+
+			for _, cfg := range []HederaConfig{fromJson, fromYaml} {
+				if cfg.BroadcastStream.Type != tt.expectType {
+					t.Errorf("unexpected broadcast stream type %s", cfg.BroadcastStream.Type)
+				}
+				switch tt.expectType {
+				case contracts.MqttStream:
+					mqtt, ok := cfg.BroadcastStream.Config.(MqttConfig)
+					if !ok || mqtt.Provider.Uri() != "tcp://localhost:1883" {
+						t.Errorf("unexpected broadcast stream config %v", cfg.BroadcastStream.Config)
+					}
+				case contracts.NatsStream:
+					nats, ok := cfg.BroadcastStream.Config.(NatsConfig)
+					if !ok || nats.Provider.Uri() != "nats://localhost:4222" {
+						t.Errorf("unexpected broadcast stream config %v", cfg.BroadcastStream.Config)
+					}
+				}
+			}
+		})
+	}
+}
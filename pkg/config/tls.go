@@ -0,0 +1,27 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+// TlsInfo describes how to secure the connection to a stream provider. Supplying a client certificate and key
+// enables mutual TLS.
+type TlsInfo struct {
+	Enabled            bool   `json:"enabled,omitempty" yaml:"enabled"`
+	CaPath             string `json:"caPath,omitempty" yaml:"caPath"`         // PEM bundle of the only CAs trusted, replacing the system pool
+	CertPath           string `json:"certPath,omitempty" yaml:"certPath"`     // PEM encoded client certificate
+	KeyPath            string `json:"keyPath,omitempty" yaml:"keyPath"`       // PEM encoded client private key
+	ServerName         string `json:"serverName,omitempty" yaml:"serverName"` // Overrides the host name used to verify the server certificate
+	MinVersion         string `json:"minVersion,omitempty" yaml:"minVersion"` // One of "1.0", "1.1", "1.2" or "1.3", defaults to "1.2"
+	InsecureSkipVerify bool   `json:"insecureSkipVerify,omitempty" yaml:"insecureSkipVerify"`
+}
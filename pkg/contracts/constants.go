@@ -72,12 +72,21 @@ const (
 	ConsoleStream StreamType = "console"
 	MockStream    StreamType = "mock"
 	MqttStream    StreamType = "mqtt"
-	PravegaStream StreamType = "pravega" // Currently unsupported but indicating extension point
+	PravegaStream StreamType = "pravega"
 	HederaStream  StreamType = "hedera"
+	KafkaStream   StreamType = "kafka"
+	NatsStream    StreamType = "nats"
+	AmqpStream    StreamType = "amqp"
+	WebhookStream StreamType = "webhook"
+	FileStream    StreamType = "file"
+	RedisStream   StreamType = "redis"
+	GrpcStream    StreamType = "grpc"
 )
 
 func (t StreamType) Validate() bool {
-	if t == MockStream || t == MqttStream || t == PravegaStream || t == ConsoleStream || t == HederaStream {
+	if t == MockStream || t == MqttStream || t == PravegaStream || t == ConsoleStream || t == HederaStream ||
+		t == KafkaStream || t == NatsStream || t == AmqpStream || t == WebhookStream ||
+		t == FileStream || t == RedisStream || t == GrpcStream {
 		return true
 	}
 	return false
@@ -150,3 +159,120 @@ func (l LayerType) Validate() bool {
 		return false
 	}
 }
+
+type ExecutionMode string
+
+const (
+	SequentialExecution ExecutionMode = "sequential"
+	ConcurrentExecution ExecutionMode = "concurrent"
+)
+
+func (m ExecutionMode) Validate() bool {
+	if m == SequentialExecution || m == ConcurrentExecution {
+		return true
+	}
+	return false
+}
+
+type FailurePolicy string
+
+const (
+	FailFast       FailurePolicy = "fail-fast" // Abort the action on the first annotator failure
+	CollectPartial FailurePolicy = "partial"   // Publish the annotations that succeeded and report the failures
+)
+
+func (p FailurePolicy) Validate() bool {
+	if p == FailFast || p == CollectPartial {
+		return true
+	}
+	return false
+}
+
+type OverflowPolicy string
+
+const (
+	OverflowBlock      OverflowPolicy = "block"       // Wait for space in the queue
+	OverflowDropOldest OverflowPolicy = "drop-oldest" // Evict the oldest queued message to make room
+	OverflowDropNewest OverflowPolicy = "drop-newest" // Reject the message being enqueued
+)
+
+func (p OverflowPolicy) Validate() bool {
+	if p == OverflowBlock || p == OverflowDropOldest || p == OverflowDropNewest {
+		return true
+	}
+	return false
+}
+
+type FsyncPolicy string
+
+const (
+	FsyncNever    FsyncPolicy = "never"    // Leave flushing to the operating system, the file is synced on rotation and close
+	FsyncAlways   FsyncPolicy = "always"   // Sync after every write
+	FsyncInterval FsyncPolicy = "interval" // Sync periodically in the background
+)
+
+func (p FsyncPolicy) Validate() bool {
+	if p == FsyncNever || p == FsyncAlways || p == FsyncInterval {
+		return true
+	}
+	return false
+}
+
+type ConsoleFormat string
+
+const (
+	ConsoleText   ConsoleFormat = "text"   // A line per message naming its action and type followed by the content
+	ConsoleTable  ConsoleFormat = "table"  // An aligned table per message
+	ConsoleJson   ConsoleFormat = "json"   // An indented JSON object per message
+	ConsoleNdjson ConsoleFormat = "ndjson" // A JSON object per line
+)
+
+func (f ConsoleFormat) Validate() bool {
+	if f == ConsoleText || f == ConsoleTable || f == ConsoleJson || f == ConsoleNdjson {
+		return true
+	}
+	return false
+}
+
+type ConsoleOutput string
+
+const (
+	ConsoleStdout ConsoleOutput = "stdout"
+	ConsoleStderr ConsoleOutput = "stderr"
+)
+
+func (o ConsoleOutput) Validate() bool {
+	if o == ConsoleStdout || o == ConsoleStderr {
+		return true
+	}
+	return false
+}
+
+type BudgetPolicy string
+
+const (
+	BudgetPause    BudgetPolicy = "pause"    // Reject publishes until the budget allows spending again
+	BudgetFallback BudgetPolicy = "fallback" // Publish to a secondary stream until the budget allows spending again
+)
+
+func (p BudgetPolicy) Validate() bool {
+	if p == BudgetPause || p == BudgetFallback {
+		return true
+	}
+	return false
+}
+
+type FanoutPolicy string
+
+const (
+	FanoutAll        FanoutPolicy = "all"         // Every stream must accept the message
+	FanoutQuorum     FanoutPolicy = "quorum"      // A minimum number of streams must accept the message
+	FanoutBestEffort FanoutPolicy = "best-effort" // At least one stream must accept the message
+)
+
+func (p FanoutPolicy) Validate() bool {
+	if p == FanoutAll || p == FanoutQuorum || p == FanoutBestEffort {
+		return true
+	}
+	return false
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// ErrNotBootstrapped is returned when an SDK method is called before BootstrapHandler has successfully connected
+// to the configured stream provider.
+var ErrNotBootstrapped = errors.New("sdk stream provider not initialized, call BootstrapHandler first")
+
+// ErrQueueFull is returned in async mode under the contracts.OverflowDropNewest policy when the annotations could not
+// be enqueued for publishing.
+var ErrQueueFull = errors.New("async publish queue is full")
+
+// ErrStreamClosed is returned in async or batch mode when annotations are submitted after the stream has been shut
+// down.
+var ErrStreamClosed = errors.New("stream has been closed")
+
+// ErrStreamNotConnected is returned in batch mode when annotations are submitted before the stream has been
+// connected, since no batch could be published until then.
+var ErrStreamNotConnected = errors.New("stream has not been connected")
+
+// AnnotatorError reports the failure of an individual annotator while handling an SDK action.
+type AnnotatorError struct {
+	Action    message.SdkAction // Action is the SDK action being handled when the annotator failed
+	Index     int               // Index is the position of the annotator supplied to NewSdk, -1 for Mutate's source annotator
+	Annotator string            // Annotator is the concrete type name of the failing annotator
+	Err       error             // Err is the error returned by the annotator
+}
+
+func (e *AnnotatorError) Error() string {
+	return fmt.Sprintf("%s: annotator %d (%s) failed: %v", e.Action, e.Index, e.Annotator, e.Err)
+}
+
+func (e *AnnotatorError) Unwrap() error {
+	return e.Err
+}
+
+// StreamError reports the failure to publish an annotation list to the configured stream provider.
+type StreamError struct {
+	Action message.SdkAction    // Action is the SDK action whose annotations could not be published
+	Index  int                  // Index is the position of the stream in SdkInfo.Streams, -1 unless publishing to several streams
+	Name   string               // Name is the configured name of the stream, if any
+	Stream contracts.StreamType // Stream is the type of stream provider that rejected the publish
+	Err    error                // Err is the error returned by the stream provider
+}
+
+func (e *StreamError) Error() string {
+	return fmt.Sprintf("%s: publish to %s failed: %v", e.Action, e.describe(), e.Err)
+}
+
+// describe identifies the stream by its position among several streams and its name, when known.
+func (e *StreamError) describe() string {
+	switch {
+	case e.Index >= 0 && e.Name != "":
+		return fmt.Sprintf("stream %d %q (%s)", e.Index, e.Name, e.Stream)
+	case e.Index >= 0:
+		return fmt.Sprintf("stream %d (%s)", e.Index, e.Stream)
+	case e.Name != "":
+		return fmt.Sprintf("%s stream %q", e.Stream, e.Name)
+	case e.Stream != "":
+		return fmt.Sprintf("%s stream", e.Stream)
+	}
+	return "streams"
+}
+
+func (e *StreamError) Unwrap() error {
+	return e.Err
+}
+
+// PartialAnnotationError is returned under the contracts.CollectPartial failure policy when one or more annotators
+// failed. The annotations that succeeded have still been published; Errors describes the ones that did not.
+type PartialAnnotationError struct {
+	Action message.SdkAction
+	Errors []*AnnotatorError
+}
+
+func (e *PartialAnnotationError) Error() string {
+	return fmt.Sprintf("%s: %d annotator(s) failed, remaining annotations published: %v", e.Action, len(e.Errors), e.Unwrap())
+}
+
+func (e *PartialAnnotationError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
+
+// FanoutError is returned when publishing to multiple streams did not satisfy the configured contracts.FanoutPolicy.
+// Errors holds the failure of each stream that did not accept the annotations.
+type FanoutError struct {
+	Action    message.SdkAction
+	Succeeded int // Succeeded is the number of streams that accepted the annotations
+	Required  int // Required is the number of streams the policy required to accept them
+	Errors    []*StreamError
+}
+
+func (e *FanoutError) Error() string {
+	return fmt.Sprintf("%s: published to %d of %d required streams: %v", e.Action, e.Succeeded, e.Required, e.Unwrap())
+}
+
+func (e *FanoutError) Unwrap() []error {
+	errs := make([]error, len(e.Errors))
+	for i, err := range e.Errors {
+		errs[i] = err
+	}
+	return errs
+}
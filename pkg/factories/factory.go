@@ -19,23 +19,31 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/project-alvarium/alvarium-sdk-go/internal/amqp"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/annotators"
 	httpAnnotators "github.com/project-alvarium/alvarium-sdk-go/internal/annotators/http"
 	handler "github.com/project-alvarium/alvarium-sdk-go/internal/annotators/http/handler"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/console"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/file"
+	grpcStream "github.com/project-alvarium/alvarium-sdk-go/internal/grpc"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/hashprovider/md5"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/hashprovider/none"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/hashprovider/sha256"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/hedera"
-	"github.com/project-alvarium/alvarium-sdk-go/internal/mock"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/kafka"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/mqtt"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/nats"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/pravega"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/redis"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/signprovider/ecdsa/secp256k1"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/signprovider/ecdsa/x509"
 	"github.com/project-alvarium/alvarium-sdk-go/internal/signprovider/ed25519"
+	"github.com/project-alvarium/alvarium-sdk-go/internal/webhook"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/mock"
 )
 
 func NewStreamProvider(cfg config.StreamInfo, logger interfaces.Logger) (interfaces.StreamProvider, error) {
@@ -51,20 +59,142 @@ func NewStreamProvider(cfg config.StreamInfo, logger interfaces.Logger) (interfa
 		if !ok {
 			return nil, errors.New("invalid cast for MqttStream")
 		}
-		return mqtt.NewMqttPublisher(info, logger), nil
+		return mqtt.NewMqttPublisher(info, logger)
+	case contracts.NatsStream:
+		info, ok := cfg.Config.(config.NatsConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for NatsStream")
+		}
+		return nats.NewNatsPublisher(info, logger)
+	case contracts.AmqpStream:
+		info, ok := cfg.Config.(config.AmqpConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for AmqpStream")
+		}
+		return amqp.NewAmqpPublisher(info, logger)
+	case contracts.WebhookStream:
+		info, ok := cfg.Config.(config.WebhookConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for WebhookStream")
+		}
+		var signer interfaces.SignatureProvider
+		if info.Signature != nil {
+			s, err := NewSignatureProvider(info.Signature.PrivateKey.Type)
+			if err != nil {
+				return nil, err
+			}
+			signer = s
+		}
+		return webhook.NewWebhookPublisher(info, signer, logger)
+	case contracts.FileStream:
+		info, ok := cfg.Config.(config.FileConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for FileStream")
+		}
+		return file.NewFilePublisher(info, logger)
+	case contracts.RedisStream:
+		info, ok := cfg.Config.(config.RedisConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for RedisStream")
+		}
+		return redis.NewRedisPublisher(info, logger)
+	case contracts.GrpcStream:
+		info, ok := cfg.Config.(config.GrpcConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for GrpcStream")
+		}
+		return grpcStream.NewGrpcPublisher(info, logger)
 	case contracts.ConsoleStream:
-		return console.NewConsolePublisher(logger), nil
+		// The configuration may be omitted, in which case the defaults apply
+		var info config.ConsoleConfig
+		if cfg.Config != nil {
+			var ok bool
+			info, ok = cfg.Config.(config.ConsoleConfig)
+			if !ok {
+				return nil, errors.New("invalid cast for ConsoleStream")
+			}
+		}
+		var signature interfaces.SignatureProvider
+		if info.PublicKey != nil {
+			s, err := NewSignatureProvider(info.PublicKey.Type)
+			if err != nil {
+				return nil, err
+			}
+			signature = s
+		}
+		return console.NewConsolePublisher(info, signature, logger)
 	case contracts.HederaStream:
 		info, ok := cfg.Config.(config.HederaConfig)
 		if !ok {
 			return nil, errors.New("invalid cast for HederaStream")
 		}
-		return hedera.NewHederaPublisher(info, logger)
+		var broadcast interfaces.StreamProvider
+		if info.ShouldBroadcastTopic {
+			var err error
+			broadcast, err = NewStreamProvider(info.BroadcastStream, logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+		var fallback interfaces.StreamProvider
+		if info.Budget.OnExhausted == contracts.BudgetFallback && info.Budget.FallbackStream != nil {
+			var err error
+			fallback, err = NewStreamProvider(*info.Budget.FallbackStream, logger)
+			if err != nil {
+				return nil, err
+			}
+		}
+		return hedera.NewHederaPublisher(info, broadcast, fallback, logger)
+	case contracts.PravegaStream:
+		info, ok := cfg.Config.(config.PravegaConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for PravegaStream")
+		}
+		return pravega.NewPravegaPublisher(info, logger)
+	case contracts.KafkaStream:
+		info, ok := cfg.Config.(config.KafkaConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for KafkaStream")
+		}
+		return kafka.NewKafkaPublisher(info, logger)
 	default:
 		return nil, fmt.Errorf("unrecognized config Type value %s", cfg.Type)
 	}
 }
 
+// NewStreamSubscriber returns a subscriber reading back what the configured stream provider publishes. Not every
+// stream type supports subscribing.
+func NewStreamSubscriber(cfg config.StreamInfo, logger interfaces.Logger) (interfaces.StreamSubscriber, error) {
+	switch cfg.Type {
+	case contracts.MqttStream:
+		info, ok := cfg.Config.(config.MqttConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for MqttStream")
+		}
+		return mqtt.NewMqttSubscriber(info, logger)
+	case contracts.FileStream:
+		info, ok := cfg.Config.(config.FileConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for FileStream")
+		}
+		return file.NewFileSubscriber(info, logger)
+	case contracts.HederaStream:
+		info, ok := cfg.Config.(config.HederaConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for HederaStream")
+		}
+		return hedera.NewHederaSubscriber(info, logger)
+	case contracts.RedisStream:
+		info, ok := cfg.Config.(config.RedisConfig)
+		if !ok {
+			return nil, errors.New("invalid cast for RedisStream")
+		}
+		return redis.NewRedisSubscriber(info, logger)
+	default:
+		return nil, fmt.Errorf("subscribing is not supported for config Type value %s", cfg.Type)
+	}
+}
+
 func NewHashProvider(hash contracts.HashType) (interfaces.HashProvider, error) {
 	switch hash {
 	case contracts.MD5Hash:
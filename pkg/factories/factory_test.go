@@ -52,16 +52,180 @@ func TestStreamProviderFactory(t *testing.T) {
 			PrivateKeyPath: "../../test/keys/hedera/hedera.private",
 		},
 	}
+	pass5 := config.StreamInfo{
+		Type: contracts.KafkaStream,
+		Config: config.KafkaConfig{
+			Brokers:     []string{"localhost:9092"},
+			Topics:      []string{"alvarium"},
+			Compression: "gzip",
+			Idempotent:  true,
+		},
+	}
+
+	pass7 := config.StreamInfo{
+		Type: contracts.NatsStream,
+		Config: config.NatsConfig{
+			Provider:  config.ServiceInfo{Host: "localhost", Port: 4222, Protocol: "nats"},
+			Subjects:  []string{"alvarium.annotations"},
+			JetStream: true,
+		},
+	}
+
+	fail5 := config.StreamInfo{
+		Type:   contracts.NatsStream,
+		Config: config.NatsConfig{},
+	}
+
+	pass8 := config.StreamInfo{
+		Type: contracts.AmqpStream,
+		Config: config.AmqpConfig{
+			Provider:    config.ServiceInfo{Host: "localhost", Port: 5672, Protocol: "amqp"},
+			Exchange:    "alvarium",
+			RoutingKeys: []string{"annotations"},
+		},
+	}
+
+	fail6 := config.StreamInfo{
+		Type:   contracts.AmqpStream,
+		Config: config.AmqpConfig{Exchange: "alvarium"},
+	}
+
+	pass9 := config.StreamInfo{
+		Type: contracts.WebhookStream,
+		Config: config.WebhookConfig{
+			Urls: []string{"https://collector.example.com/annotations"},
+			Signature: &config.SignatureInfo{
+				PrivateKey: config.KeyInfo{Type: contracts.KeyEd25519, Path: "./private.key"},
+			},
+		},
+	}
+
+	fail7 := config.StreamInfo{
+		Type:   contracts.WebhookStream,
+		Config: config.WebhookConfig{},
+	}
+
+	pass10 := config.StreamInfo{
+		Type:   contracts.FileStream,
+		Config: config.FileConfig{Path: "./annotations.jsonl"},
+	}
+
+	fail8 := config.StreamInfo{
+		Type:   contracts.FileStream,
+		Config: config.FileConfig{},
+	}
+
+	fail9 := config.StreamInfo{
+		Type: contracts.MqttStream,
+		Config: config.MqttConfig{
+			Topics: []string{"topic"},
+			Tls:    config.TlsInfo{Enabled: true, CaPath: "./missing.pem"},
+		},
+	}
+
 	fail := config.StreamInfo{
 		Type:   "invalid",
 		Config: config.MqttConfig{},
 	}
 
 	fail2 := config.StreamInfo{
-		Type:   "pravega",
+		Type:   contracts.PravegaStream,
+		Config: config.MockStreamConfig{},
+	}
+
+	pass6 := config.StreamInfo{
+		Type: contracts.PravegaStream,
+		Config: config.PravegaConfig{
+			Provider: config.ServiceInfo{Host: "localhost", Port: 54672},
+			Scope:    "alvarium",
+			Stream:   "annotations",
+		},
+	}
+
+	fail4 := config.StreamInfo{
+		Type:   contracts.PravegaStream,
+		Config: config.PravegaConfig{Scope: "alvarium"},
+	}
+
+	fail3 := config.StreamInfo{
+		Type: contracts.KafkaStream,
+		Config: config.KafkaConfig{
+			Brokers:    []string{"localhost:9092"},
+			Acks:       config.KafkaAcksLeader,
+			Idempotent: true,
+		},
+	}
+
+	pass11 := config.StreamInfo{
+		Type: contracts.HederaStream,
+		Config: config.HederaConfig{
+			NetType:              contracts.Local,
+			AccountId:            "0.0.1001",
+			PrivateKeyPath:       "../../test/keys/hedera/hedera.private",
+			ShouldBroadcastTopic: true,
+			BroadcastStream:      config.StreamInfo{Type: contracts.ConsoleStream},
+		},
+	}
+
+	fail10 := config.StreamInfo{
+		Type: contracts.HederaStream,
+		Config: config.HederaConfig{
+			NetType:              contracts.Local,
+			AccountId:            "0.0.1001",
+			PrivateKeyPath:       "../../test/keys/hedera/hedera.private",
+			ShouldBroadcastTopic: true,
+			BroadcastStream:      config.StreamInfo{Type: contracts.FileStream, Config: config.FileConfig{}},
+		},
+	}
+
+	pass12 := config.StreamInfo{
+		Type: contracts.HederaStream,
+		Config: config.HederaConfig{
+			NetType:        contracts.Local,
+			AccountId:      "0.0.1001",
+			PrivateKeyPath: "../../test/keys/hedera/hedera.private",
+			Budget: config.HederaBudget{
+				HourlyLimit:    1,
+				OnExhausted:    contracts.BudgetFallback,
+				FallbackStream: &config.StreamInfo{Type: contracts.ConsoleStream},
+			},
+		},
+	}
+
+	pass13 := config.StreamInfo{
+		Type: contracts.ConsoleStream,
+		Config: config.ConsoleConfig{
+			Format:            contracts.ConsoleTable,
+			ExpandAnnotations: true,
+			PublicKey:         &config.KeyInfo{Type: contracts.KeyEd25519, Path: "../../test/keys/ed25519/public.key"},
+		},
+	}
+
+	fail11 := config.StreamInfo{
+		Type:   contracts.ConsoleStream,
 		Config: config.MockStreamConfig{},
 	}
 
+	pass14 := config.StreamInfo{
+		Type:   contracts.RedisStream,
+		Config: config.RedisConfig{Streams: []string{"alvarium"}},
+	}
+
+	fail12 := config.StreamInfo{
+		Type:   contracts.RedisStream,
+		Config: config.RedisConfig{},
+	}
+
+	pass15 := config.StreamInfo{
+		Type:   contracts.GrpcStream,
+		Config: config.GrpcConfig{Provider: config.ServiceInfo{Host: "localhost", Port: 50051}},
+	}
+
+	fail13 := config.StreamInfo{
+		Type:   contracts.GrpcStream,
+		Config: config.RedisConfig{},
+	}
+
 	tests := []struct {
 		name         string
 		providerType config.StreamInfo
@@ -71,8 +235,30 @@ func TestStreamProviderFactory(t *testing.T) {
 		{"valid mqtt type", pass2, false},
 		{"valid console type", pass3, false},
 		{"valid hedera type", pass4, false},
+		{"valid kafka type", pass5, false},
+		{"invalid kafka idempotent acks", fail3, true},
 		{"invalid random type", fail, true},
-		{"unimplemented pravega type", fail2, true},
+		{"invalid pravega config cast", fail2, true},
+		{"valid pravega type", pass6, false},
+		{"invalid pravega missing stream", fail4, true},
+		{"valid nats type", pass7, false},
+		{"invalid nats missing subjects", fail5, true},
+		{"valid amqp type", pass8, false},
+		{"invalid amqp missing routing keys", fail6, true},
+		{"valid webhook type", pass9, false},
+		{"invalid webhook missing urls", fail7, true},
+		{"valid file type", pass10, false},
+		{"invalid file missing path", fail8, true},
+		{"invalid mqtt tls", fail9, true},
+		{"valid hedera broadcast", pass11, false},
+		{"invalid hedera broadcast stream", fail10, true},
+		{"valid hedera budget fallback", pass12, false},
+		{"valid console config", pass13, false},
+		{"invalid console config cast", fail11, true},
+		{"valid redis type", pass14, false},
+		{"invalid redis missing streams", fail12, true},
+		{"valid grpc type", pass15, false},
+		{"invalid grpc config cast", fail13, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -82,6 +268,83 @@ func TestStreamProviderFactory(t *testing.T) {
 	}
 }
 
+func TestStreamSubscriberFactory(t *testing.T) {
+	logger := NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelDebug})
+
+	pass := config.StreamInfo{
+		Type:   contracts.MqttStream,
+		Config: config.MqttConfig{Topics: []string{"alvarium"}},
+	}
+
+	pass2 := config.StreamInfo{
+		Type:   contracts.FileStream,
+		Config: config.FileConfig{Path: "./annotations.jsonl", OffsetPath: "./annotations.offset"},
+	}
+
+	pass3 := config.StreamInfo{
+		Type: contracts.HederaStream,
+		Config: config.HederaConfig{
+			NetType:    contracts.Local,
+			Topics:     []string{"0.0.1001"},
+			OffsetPath: "./hedera.offset",
+		},
+	}
+
+	fail := config.StreamInfo{
+		Type:   contracts.MqttStream,
+		Config: config.MqttConfig{},
+	}
+
+	fail4 := config.StreamInfo{
+		Type: contracts.HederaStream,
+		Config: config.HederaConfig{
+			NetType: contracts.Local,
+			Topics:  []string{"not a topic"},
+		},
+	}
+
+	fail2 := config.StreamInfo{
+		Type:   contracts.FileStream,
+		Config: config.MqttConfig{},
+	}
+
+	fail3 := config.StreamInfo{
+		Type: contracts.ConsoleStream,
+	}
+
+	pass4 := config.StreamInfo{
+		Type:   contracts.RedisStream,
+		Config: config.RedisConfig{Streams: []string{"alvarium"}, Consumer: "verifier-1"},
+	}
+
+	fail5 := config.StreamInfo{
+		Type:   contracts.RedisStream,
+		Config: config.MqttConfig{},
+	}
+
+	tests := []struct {
+		name        string
+		cfg         config.StreamInfo
+		expectError bool
+	}{
+		{"valid mqtt type", pass, false},
+		{"valid file type", pass2, false},
+		{"invalid mqtt missing topics", fail, true},
+		{"invalid file config cast", fail2, true},
+		{"valid hedera type", pass3, false},
+		{"invalid unsupported type", fail3, true},
+		{"invalid hedera topic", fail4, true},
+		{"valid redis type", pass4, false},
+		{"invalid redis config cast", fail5, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewStreamSubscriber(tt.cfg, logger)
+			test.CheckError(err, tt.expectError, tt.name, t)
+		})
+	}
+}
+
 func TestHashProviderFactory(t *testing.T) {
 	tests := []struct {
 		name         string
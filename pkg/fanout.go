@@ -0,0 +1,236 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// fanoutMember is one of the streams published to by a fanoutStream.
+type fanoutMember struct {
+	index     int // position in SdkInfo.Streams
+	name      string
+	kind      contracts.StreamType
+	stream    interfaces.StreamProvider
+	mutex     sync.Mutex // guards connected
+	connected bool
+}
+
+// fanoutStream is a composite StreamProvider that publishes every message to all of its members concurrently.
+// Whether the publish as a whole succeeded is decided by the configured policy. Members that could not be connected
+// are retried on each publish.
+type fanoutStream struct {
+	cfg      config.FanoutInfo
+	members  []*fanoutMember
+	required int
+	logger   interfaces.Logger
+}
+
+func newFanoutStream(cfg config.FanoutInfo, streams []config.StreamInfo, logger interfaces.Logger) (*fanoutStream, error) {
+	members := make([]*fanoutMember, len(streams))
+	for i, info := range streams {
+		stream, err := factories.NewStreamProvider(info, logger)
+		if err != nil {
+			return nil, fmt.Errorf("stream %d (%s): %w", i, describeMember(info.Name, info.Type), err)
+		}
+		members[i] = &fanoutMember{index: i, name: info.Name, kind: info.Type, stream: stream}
+	}
+	return newFanoutOf(cfg, members, logger)
+}
+
+func newFanoutOf(cfg config.FanoutInfo, members []*fanoutMember, logger interfaces.Logger) (*fanoutStream, error) {
+	if len(members) == 0 {
+		return nil, errors.New("at least one stream must be provided")
+	}
+	if cfg.Policy == "" {
+		cfg.Policy = contracts.FanoutAll
+	}
+
+	required := len(members)
+	switch cfg.Policy {
+	case contracts.FanoutBestEffort:
+		required = 1
+	case contracts.FanoutQuorum:
+		required = len(members)/2 + 1
+		if cfg.Quorum > 0 {
+			required = cfg.Quorum
+		}
+		if required > len(members) {
+			return nil, fmt.Errorf("quorum of %d exceeds the %d configured streams", required, len(members))
+		}
+	}
+
+	return &fanoutStream{
+		cfg:      cfg,
+		members:  members,
+		required: required,
+		logger:   logger,
+	}, nil
+}
+
+// Connect connects every member and succeeds if the policy is satisfied. Members that failed to connect are
+// retried on the next publish.
+func (p *fanoutStream) Connect() error {
+	errs := p.each(func(_ int, m *fanoutMember) error {
+		return m.connect()
+	})
+
+	if len(p.members)-len(errs) < p.required {
+		return fmt.Errorf("connected to %d of %d required streams: %w", len(p.members)-len(errs), p.required,
+			joinMemberErrors(errs))
+	}
+	for _, err := range errs {
+		p.logger.Write(slog.LevelWarn, fmt.Sprintf("%s, will retry on publish", err.Error()))
+	}
+	return nil
+}
+
+// Publish sends the message to every member. If the policy is satisfied, failures of individual members are logged
+// and nil is returned. Otherwise a *FanoutError describing each failed member is returned.
+func (p *fanoutStream) Publish(msg message.PublishWrapper) error {
+	_, err := p.publish(msg, false)
+	return err
+}
+
+// PublishWithProof publishes like Publish, using PublishWithProof for members that implement
+// interfaces.ProofProvider. It returns their proofs in member order.
+func (p *fanoutStream) PublishWithProof(msg message.PublishWrapper) ([]message.ConsensusProof, error) {
+	return p.publish(msg, true)
+}
+
+func (p *fanoutStream) publish(msg message.PublishWrapper, withProof bool) ([]message.ConsensusProof, error) {
+	proofs := make([][]message.ConsensusProof, len(p.members))
+	failed := p.each(func(i int, m *fanoutMember) error {
+		err := m.connect()
+		if err != nil {
+			return err
+		}
+		if prover, ok := m.stream.(interfaces.ProofProvider); ok && withProof {
+			proofs[i], err = prover.PublishWithProof(msg)
+			return err
+		}
+		return m.stream.Publish(msg)
+	})
+
+	var all []message.ConsensusProof
+	for _, memberProofs := range proofs {
+		all = append(all, memberProofs...)
+	}
+
+	var errs []*StreamError
+	for _, err := range failed {
+		errs = append(errs, &StreamError{Action: msg.Action, Index: err.member.index, Name: err.member.name,
+			Stream: err.member.kind, Err: err.err})
+	}
+
+	succeeded := len(p.members) - len(errs)
+	if succeeded < p.required {
+		return all, &FanoutError{Action: msg.Action, Succeeded: succeeded, Required: p.required, Errors: errs}
+	}
+	for _, err := range errs {
+		p.logger.Write(slog.LevelWarn, fmt.Sprintf("%s, satisfied %s policy with %d streams", err.Error(),
+			p.cfg.Policy, succeeded))
+	}
+	return all, nil
+}
+
+// Close closes every connected member.
+func (p *fanoutStream) Close() error {
+	errs := p.each(func(_ int, m *fanoutMember) error {
+		m.mutex.Lock()
+		defer m.mutex.Unlock()
+		if !m.connected {
+			return nil
+		}
+		m.connected = false
+		return m.stream.Close()
+	})
+	return joinMemberErrors(errs)
+}
+
+// memberError associates a failure with the member that produced it.
+type memberError struct {
+	member *fanoutMember
+	err    error
+}
+
+func (e *memberError) Error() string {
+	return fmt.Sprintf("stream %d (%s): %v", e.member.index, describeMember(e.member.name, e.member.kind), e.err)
+}
+
+// describeMember names a member by its configured name and type.
+func describeMember(name string, kind contracts.StreamType) string {
+	if name != "" {
+		return fmt.Sprintf("%q, %s", name, kind)
+	}
+	return string(kind)
+}
+
+func (e *memberError) Unwrap() error {
+	return e.err
+}
+
+func joinMemberErrors(errs []*memberError) error {
+	joined := make([]error, len(errs))
+	for i, err := range errs {
+		joined[i] = err
+	}
+	return errors.Join(joined...)
+}
+
+// each runs fn against all members concurrently and returns the failures in member order.
+func (p *fanoutStream) each(fn func(i int, m *fanoutMember) error) []*memberError {
+	results := make([]error, len(p.members))
+	var wg sync.WaitGroup
+	for i, m := range p.members {
+		wg.Add(1)
+		go func(i int, m *fanoutMember) {
+			defer wg.Done()
+			results[i] = fn(i, m)
+		}(i, m)
+	}
+	wg.Wait()
+
+	var errs []*memberError
+	for i, err := range results {
+		if err != nil {
+			errs = append(errs, &memberError{member: p.members[i], err: err})
+		}
+	}
+	return errs
+}
+
+func (m *fanoutMember) connect() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	if m.connected {
+		return nil
+	}
+	err := m.stream.Connect()
+	if err != nil {
+		return err
+	}
+	m.connected = true
+	return nil
+}
@@ -0,0 +1,195 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/mock"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+// unreachableStream fails to connect, standing in for a broker that is down.
+type unreachableStream struct {
+	failingStream
+}
+
+func (p unreachableStream) Connect() error {
+	return errors.New("connection refused")
+}
+
+func TestFanoutStream(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	streamErr := errors.New("stream failure")
+	msg := message.PublishWrapper{Action: message.ActionCreate, MessageType: "string", Content: []byte("content")}
+
+	tests := []struct {
+		name          string
+		cfg           config.FanoutInfo
+		streams       []interfaces.StreamProvider
+		expectConnect bool // whether Connect is expected to fail
+		expectError   bool
+		expectFailed  []contracts.StreamType
+	}{
+		{"all succeed", config.FanoutInfo{}, []interfaces.StreamProvider{&recordingStream{}, &recordingStream{}}, false, false, nil},
+		{"all with one failure", config.FanoutInfo{Policy: contracts.FanoutAll},
+			[]interfaces.StreamProvider{&recordingStream{}, failingStream{err: streamErr}}, false, true,
+			[]contracts.StreamType{contracts.HederaStream}},
+		{"best effort with one success", config.FanoutInfo{Policy: contracts.FanoutBestEffort},
+			[]interfaces.StreamProvider{failingStream{err: streamErr}, &recordingStream{}}, false, false, nil},
+		{"best effort all fail", config.FanoutInfo{Policy: contracts.FanoutBestEffort},
+			[]interfaces.StreamProvider{failingStream{err: streamErr}, failingStream{err: streamErr}}, false, true,
+			[]contracts.StreamType{contracts.KafkaStream, contracts.HederaStream}},
+		{"default quorum met", config.FanoutInfo{Policy: contracts.FanoutQuorum},
+			[]interfaces.StreamProvider{&recordingStream{}, unreachableStream{}, &recordingStream{}}, false, false, nil},
+		{"explicit quorum missed", config.FanoutInfo{Policy: contracts.FanoutQuorum, Quorum: 3},
+			[]interfaces.StreamProvider{&recordingStream{}, &recordingStream{}, failingStream{err: streamErr}}, false, true,
+			[]contracts.StreamType{contracts.MqttStream}},
+		{"connect below policy", config.FanoutInfo{},
+			[]interfaces.StreamProvider{&recordingStream{}, unreachableStream{}}, true, false, nil},
+	}
+	kinds := []contracts.StreamType{contracts.KafkaStream, contracts.HederaStream, contracts.MqttStream}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := make([]*fanoutMember, len(tt.streams))
+			for i, s := range tt.streams {
+				members[i] = &fanoutMember{index: i, kind: kinds[i], stream: s}
+			}
+			p, err := newFanoutOf(tt.cfg, members, logger)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			err = p.Connect()
+			test.CheckError(err, tt.expectConnect, tt.name, t)
+			if err != nil {
+				return
+			}
+
+			err = p.Publish(msg)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				var fanout *FanoutError
+				if !errors.As(err, &fanout) {
+					t.Fatalf("expected FanoutError, got %v", err)
+				}
+				if len(fanout.Errors) != len(tt.expectFailed) {
+					t.Fatalf("expected %d stream errors, got %v", len(tt.expectFailed), fanout.Errors)
+				}
+				for i, e := range fanout.Errors {
+					if e.Stream != tt.expectFailed[i] || kinds[e.Index] != e.Stream || e.Action != msg.Action ||
+						!errors.Is(e, streamErr) {
+						t.Errorf("unexpected stream error %v", e)
+					}
+				}
+			}
+
+			for _, s := range tt.streams {
+				if r, ok := s.(*recordingStream); ok && len(r.published) != 1 {
+					t.Errorf("expected every available stream to receive the message, got %d", len(r.published))
+				}
+			}
+			_ = p.Close()
+		})
+	}
+}
+
+func TestFanoutStreamQuorumExceedsStreams(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	members := []*fanoutMember{{kind: contracts.MockStream, stream: &recordingStream{}}}
+
+	_, err := newFanoutOf(config.FanoutInfo{Policy: contracts.FanoutQuorum, Quorum: 2}, members, logger)
+	if err == nil {
+		t.Error("expected error when quorum exceeds the number of streams")
+	}
+}
+
+func TestFanoutStreamProofs(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	msg := message.PublishWrapper{Action: message.ActionCreate, MessageType: "string", Content: []byte("content")}
+	ledger := mock.NewMockPublisher(config.MockStreamConfig{}, logger)
+	plain := &recordingStream{}
+	members := []*fanoutMember{
+		{kind: contracts.KafkaStream, stream: plain},
+		{kind: contracts.HederaStream, stream: ledger},
+	}
+
+	p, err := newFanoutOf(config.FanoutInfo{}, members, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer p.Close()
+
+	var provider interfaces.ProofProvider = p
+	proofs, err := provider.PublishWithProof(msg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if len(proofs) != 1 || proofs[0].TopicId != string(contracts.MockStream) || proofs[0].Sequence != 1 {
+		t.Errorf("unexpected proofs %v", proofs)
+	}
+	if len(plain.published) != 1 || len(ledger.Records()) != 1 {
+		t.Errorf("expected every stream to receive the message")
+	}
+}
+
+func TestFanoutStreamMemberErrors(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	streams := []config.StreamInfo{
+		{Type: contracts.MockStream, Name: "primary", Config: config.MockStreamConfig{}},
+		{Type: contracts.MockStream, Config: config.MockStreamConfig{FailEvery: 1}},
+		{Type: contracts.MockStream, Name: "backup", Config: config.MockStreamConfig{FailEvery: 1}},
+	}
+	p, err := newFanoutStream(config.FanoutInfo{}, streams, logger)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer p.Close()
+
+	err = p.Publish(message.PublishWrapper{Action: message.ActionCreate})
+	var fanout *FanoutError
+	if !errors.As(err, &fanout) || len(fanout.Errors) != 2 {
+		t.Fatalf("expected FanoutError for two streams, got %v", err)
+	}
+	expected := []struct {
+		index   int
+		name    string
+		message string
+	}{
+		{1, "", "create: publish to stream 1 (mock) failed"},
+		{2, "backup", `create: publish to stream 2 "backup" (mock) failed`},
+	}
+	for i, e := range fanout.Errors {
+		if e.Index != expected[i].index || e.Name != expected[i].name || e.Stream != contracts.MockStream ||
+			!strings.HasPrefix(e.Error(), expected[i].message) {
+			t.Errorf("unexpected stream error %v", e)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package ingest
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/oklog/ulid/v2"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// FromWrapper converts a wrapper to its protobuf form. Annotation lists are carried as typed annotations, any other
+// content as is.
+func FromWrapper(msg message.PublishWrapper) *PublishWrapper {
+	w := &PublishWrapper{
+		Action:      string(msg.Action),
+		MessageType: msg.MessageType,
+	}
+	list, ok := msg.AnnotationList()
+	if !ok {
+		w.Content = &PublishWrapper_Raw{Raw: msg.Content}
+		return w
+	}
+
+	items := make([]*Annotation, len(list.Items))
+	for i, a := range list.Items {
+		_, offset := a.Timestamp.Zone()
+		items[i] = &Annotation{
+			Id:          a.Id.String(),
+			Key:         a.Key,
+			Hash:        string(a.Hash),
+			Host:        a.Host,
+			Tag:         a.Tag,
+			Layer:       string(a.Layer),
+			Kind:        string(a.Kind),
+			Signature:   a.Signature,
+			IsSatisfied: a.IsSatisfied,
+			Timestamp:   timestamppb.New(a.Timestamp),
+			UtcOffset:   int32(offset),
+		}
+	}
+	w.Content = &PublishWrapper_Annotations{Annotations: &AnnotationList{Items: items}}
+	return w
+}
+
+// ToWrapper converts a wrapper back from its protobuf form. Annotations are restored with the time zone offset they
+// were created with, so their signatures can still be verified.
+func ToWrapper(w *PublishWrapper) (message.PublishWrapper, error) {
+	msg := message.PublishWrapper{
+		Action:      message.SdkAction(w.GetAction()),
+		MessageType: w.GetMessageType(),
+	}
+	if w.GetAnnotations() == nil {
+		msg.Content = w.GetRaw()
+		return msg, nil
+	}
+
+	list := contracts.AnnotationList{Items: make([]contracts.Annotation, len(w.GetAnnotations().GetItems()))}
+	for i, a := range w.GetAnnotations().GetItems() {
+		id, err := ulid.ParseStrict(a.GetId())
+		if err != nil {
+			return message.PublishWrapper{}, fmt.Errorf("invalid annotation id %s: %w", a.GetId(), err)
+		}
+		var ts time.Time
+		if a.GetTimestamp() != nil {
+			ts = a.GetTimestamp().AsTime().In(time.FixedZone("", int(a.GetUtcOffset())))
+		}
+		list.Items[i] = contracts.Annotation{
+			Id:          id,
+			Key:         a.GetKey(),
+			Hash:        contracts.HashType(a.GetHash()),
+			Host:        a.GetHost(),
+			Tag:         a.GetTag(),
+			Layer:       contracts.LayerType(a.GetLayer()),
+			Kind:        contracts.AnnotationType(a.GetKind()),
+			Signature:   a.GetSignature(),
+			IsSatisfied: a.GetIsSatisfied(),
+			Timestamp:   ts,
+		}
+	}
+	b, err := json.Marshal(list)
+	if err != nil {
+		return message.PublishWrapper{}, err
+	}
+	msg.Content = b
+	return msg, nil
+}
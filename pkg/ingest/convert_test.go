@@ -0,0 +1,80 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package ingest
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestWrapperConversion(t *testing.T) {
+	annotation := contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+	annotation.Signature = "signature"
+	annotation.Tag = "tag"
+
+	// The JSON form of an annotation, which signatures are computed over, depends on the time zone
+	offset := annotation
+	offset.Timestamp = time.Date(2024, 3, 1, 12, 0, 0, 123456789, time.FixedZone("EST", -5*3600))
+	utc := annotation
+	utc.Timestamp = offset.Timestamp.UTC()
+
+	list := func(items ...contracts.Annotation) []byte {
+		b, _ := json.Marshal(contracts.AnnotationList{Items: items})
+		return b
+	}
+
+	tests := []struct {
+		name  string
+		msg   message.PublishWrapper
+		typed bool // whether the content is converted to typed annotations
+	}{
+		{"annotations", message.PublishWrapper{Action: message.ActionCreate, MessageType: message.AnnotationListType, Content: list(annotation)}, true},
+		{"time zone offset", message.PublishWrapper{Action: message.ActionMutate, MessageType: message.AnnotationListType, Content: list(offset, utc)}, true},
+		{"raw content", message.PublishWrapper{Action: message.ActionPublish, MessageType: "string", Content: []byte("raw")}, false},
+		{"invalid annotation list", message.PublishWrapper{Action: message.ActionCreate, MessageType: message.AnnotationListType, Content: []byte("not json")}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := FromWrapper(tt.msg)
+			if (w.GetAnnotations() != nil) != tt.typed {
+				t.Fatalf("unexpected content %v", w.GetContent())
+			}
+
+			msg, err := ToWrapper(w)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			if msg.Action != tt.msg.Action || msg.MessageType != tt.msg.MessageType || !bytes.Equal(msg.Content, tt.msg.Content) {
+				t.Errorf("unexpected wrapper %s %s %s", msg.Action, msg.MessageType, string(msg.Content))
+			}
+		})
+	}
+}
+
+func TestToWrapperInvalidId(t *testing.T) {
+	w := &PublishWrapper{
+		Action:      string(message.ActionCreate),
+		MessageType: message.AnnotationListType,
+		Content:     &PublishWrapper_Annotations{Annotations: &AnnotationList{Items: []*Annotation{{Id: "not a ulid"}}}},
+	}
+	_, err := ToWrapper(w)
+	test.CheckError(err, true, "invalid annotation id", t)
+}
@@ -0,0 +1,158 @@
+// Copyright 2024 Dell Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+// in compliance with the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License
+// is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+// or implied. See the License for the specific language governing permissions and limitations under
+// the License.
+
+// Annotation ingestion between SDK instances and a collector. The messages mirror contracts.Annotation,
+// contracts.AnnotationList and message.PublishWrapper.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: ingest.proto
+
+package ingest
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	Ingest_Publish_FullMethodName = "/alvarium.ingest.v1.Ingest/Publish"
+)
+
+// IngestClient is the client API for Ingest service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IngestClient interface {
+	// Publish carries messages from an SDK instance to the collector, which acknowledges each of them in turn.
+	Publish(ctx context.Context, opts ...grpc.CallOption) (Ingest_PublishClient, error)
+}
+
+type ingestClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIngestClient(cc grpc.ClientConnInterface) IngestClient {
+	return &ingestClient{cc}
+}
+
+func (c *ingestClient) Publish(ctx context.Context, opts ...grpc.CallOption) (Ingest_PublishClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Ingest_ServiceDesc.Streams[0], Ingest_Publish_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &ingestPublishClient{stream}
+	return x, nil
+}
+
+type Ingest_PublishClient interface {
+	Send(*PublishRequest) error
+	Recv() (*PublishAck, error)
+	grpc.ClientStream
+}
+
+type ingestPublishClient struct {
+	grpc.ClientStream
+}
+
+func (x *ingestPublishClient) Send(m *PublishRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *ingestPublishClient) Recv() (*PublishAck, error) {
+	m := new(PublishAck)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// IngestServer is the server API for Ingest service.
+// All implementations must embed UnimplementedIngestServer
+// for forward compatibility
+type IngestServer interface {
+	// Publish carries messages from an SDK instance to the collector, which acknowledges each of them in turn.
+	Publish(Ingest_PublishServer) error
+	mustEmbedUnimplementedIngestServer()
+}
+
+// UnimplementedIngestServer must be embedded to have forward compatible implementations.
+type UnimplementedIngestServer struct {
+}
+
+func (UnimplementedIngestServer) Publish(Ingest_PublishServer) error {
+	return status.Errorf(codes.Unimplemented, "method Publish not implemented")
+}
+func (UnimplementedIngestServer) mustEmbedUnimplementedIngestServer() {}
+
+// UnsafeIngestServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IngestServer will
+// result in compilation errors.
+type UnsafeIngestServer interface {
+	mustEmbedUnimplementedIngestServer()
+}
+
+func RegisterIngestServer(s grpc.ServiceRegistrar, srv IngestServer) {
+	s.RegisterService(&Ingest_ServiceDesc, srv)
+}
+
+func _Ingest_Publish_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(IngestServer).Publish(&ingestPublishServer{stream})
+}
+
+type Ingest_PublishServer interface {
+	Send(*PublishAck) error
+	Recv() (*PublishRequest, error)
+	grpc.ServerStream
+}
+
+type ingestPublishServer struct {
+	grpc.ServerStream
+}
+
+func (x *ingestPublishServer) Send(m *PublishAck) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *ingestPublishServer) Recv() (*PublishRequest, error) {
+	m := new(PublishRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Ingest_ServiceDesc is the grpc.ServiceDesc for Ingest service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ingest_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "alvarium.ingest.v1.Ingest",
+	HandlerType: (*IngestServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Publish",
+			Handler:       _Ingest_Publish_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "ingest.proto",
+}
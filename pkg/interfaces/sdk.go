@@ -44,4 +44,17 @@ type Sdk interface {
 	// is sent over the wire. Publish could also be useful in cases where the downstream host receiving the data isn't
 	// running Alvarium-enabled applications.
 	Publish(ctx context.Context, data []byte)
+
+	// TryCreate behaves like Create but returns any failure to the caller instead of logging it. Annotator failures
+	// are reported as *pkg.AnnotatorError and stream failures as *pkg.StreamError.
+	TryCreate(ctx context.Context, data []byte) error
+
+	// TryMutate behaves like Mutate but returns any failure to the caller instead of logging it.
+	TryMutate(ctx context.Context, old, new []byte) error
+
+	// TryTransit behaves like Transit but returns any failure to the caller instead of logging it.
+	TryTransit(ctx context.Context, data []byte) error
+
+	// TryPublish behaves like Publish but returns any failure to the caller instead of logging it.
+	TryPublish(ctx context.Context, data []byte) error
 }
@@ -20,3 +20,12 @@ type StreamProvider interface {
 	Connect() error
 	Publish(msg message.PublishWrapper) error
 }
+
+// ProofProvider is implemented by stream providers that record messages on a ledger. PublishWithProof publishes the
+// message like Publish, waits for consensus and returns the resulting proofs, one for each message submitted.
+type ProofProvider interface {
+	PublishWithProof(msg message.PublishWrapper) ([]message.ConsensusProof, error)
+}
+
+// ProofHandler receives the proofs returned by a ProofProvider for a published message.
+type ProofHandler func(msg message.PublishWrapper, proofs []message.ConsensusProof)
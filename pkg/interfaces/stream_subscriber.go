@@ -0,0 +1,37 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package interfaces
+
+import (
+	"context"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// DeliveryHandler processes a message received by a StreamSubscriber. Returning nil acknowledges the message,
+// returning an error rejects it. What happens to a rejected message depends on the stream.
+type DeliveryHandler func(ctx context.Context, msg message.Delivery) error
+
+// ErrorHandler is notified of messages that could not be delivered, each reported as a *message.DeliveryError.
+type ErrorHandler func(err error)
+
+type StreamSubscriber interface {
+	Close() error
+	Connect() error
+	// Subscribe delivers received messages to handler until ctx is cancelled or Close is called. Messages that cannot
+	// be decoded or that are rejected by handler are reported to onError, or written to the logger if it is nil.
+	// Errors that prevent receiving any further messages are returned.
+	Subscribe(ctx context.Context, handler DeliveryHandler, onError ErrorHandler) error
+}
@@ -0,0 +1,79 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+)
+
+// AnnotationListType is the MessageType of wrappers whose Content is a marshalled contracts.AnnotationList.
+var AnnotationListType = fmt.Sprintf("%T", contracts.AnnotationList{})
+
+// AnnotationList decodes the Content of the wrapper when it carries a contracts.AnnotationList. The second return
+// value is false for other message types, such as topic broadcasts, or if the content cannot be decoded.
+func (w PublishWrapper) AnnotationList() (contracts.AnnotationList, bool) {
+	var list contracts.AnnotationList
+	if w.MessageType != AnnotationListType {
+		return list, false
+	}
+	if err := json.Unmarshal(w.Content, &list); err != nil {
+		return contracts.AnnotationList{}, false
+	}
+	return list, true
+}
+
+// Annotations returns the annotations carried by the wrapper, or by the messages it groups if it is a batch envelope.
+func (w PublishWrapper) Annotations() []contracts.Annotation {
+	if items, ok := w.Batch(); ok {
+		var annotations []contracts.Annotation
+		for _, item := range items {
+			annotations = append(annotations, item.Annotations()...)
+		}
+		return annotations
+	}
+	list, _ := w.AnnotationList()
+	return list.Items
+}
+
+// DataKey returns the hash of the annotated data, taken from the first annotation carried by the wrapper. Stream
+// providers use it to keep all annotations of a given piece of data together. It is empty when the wrapper does
+// not carry annotations, or is a batch envelope grouping annotations of different data.
+func (w PublishWrapper) DataKey() string {
+	list, ok := w.AnnotationList()
+	if !ok || len(list.Items) == 0 {
+		return ""
+	}
+	return list.Items[0].Key
+}
+
+// MessageId returns the ULID of the first annotation carried by the wrapper, or by the first message of a batch
+// envelope. Since annotation IDs are unique, it identifies the wrapper itself and can be used by stream providers for
+// deduplication. It is empty when the wrapper does not carry annotations.
+func (w PublishWrapper) MessageId() string {
+	if items, ok := w.Batch(); ok {
+		if len(items) == 0 {
+			return ""
+		}
+		return items[0].MessageId()
+	}
+	list, ok := w.AnnotationList()
+	if !ok || len(list.Items) == 0 {
+		return ""
+	}
+	return list.Items[0].Id.String()
+}
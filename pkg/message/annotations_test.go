@@ -0,0 +1,62 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+)
+
+func TestPublishWrapperAnnotations(t *testing.T) {
+	annotation := contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+	list := contracts.AnnotationList{Items: []contracts.Annotation{annotation}}
+	b, _ := json.Marshal(list)
+	wrapper := PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: b}
+	envelope, _ := NewBatch([]PublishWrapper{wrapper, wrapper})
+
+	tests := []struct {
+		name      string
+		wrapper   PublishWrapper
+		expectOk  bool
+		expectKey string
+		expectId  string
+		expectLen int
+	}{
+		{"annotation list", wrapper, true, "datakey", annotation.Id.String(), 1},
+		{"empty annotation list", PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: []byte("{}")}, true, "", "", 0},
+		{"broadcast", PublishWrapper{Action: ActionBroadcast, MessageType: "string", Content: []byte("topic")}, false, "", "", 0},
+		{"malformed content", PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: []byte("{")}, false, "", "", 0},
+		{"batch envelope", envelope, false, "", annotation.Id.String(), 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := tt.wrapper.AnnotationList()
+			if ok != tt.expectOk {
+				t.Errorf("unexpected AnnotationList result %v", ok)
+			}
+			if tt.wrapper.DataKey() != tt.expectKey {
+				t.Errorf("unexpected DataKey %s", tt.wrapper.DataKey())
+			}
+			if tt.wrapper.MessageId() != tt.expectId {
+				t.Errorf("unexpected MessageId %s", tt.wrapper.MessageId())
+			}
+			if len(tt.wrapper.Annotations()) != tt.expectLen {
+				t.Errorf("unexpected Annotations %v", tt.wrapper.Annotations())
+			}
+		})
+	}
+}
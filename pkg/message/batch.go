@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// BatchType is the MessageType of envelopes whose Content is a marshalled Batch.
+var BatchType = fmt.Sprintf("%T", Batch{})
+
+// Batch is the content of an envelope grouping several messages so that they are published as one. The envelope is
+// a PublishWrapper whose Action is ActionBatch and whose MessageType is BatchType, e.g.
+//
+//	{"action":"batch","messageType":"message.Batch","content":<base64 of {"items":[<PublishWrapper>,...]}>}
+//
+// Items are in publish order and are never envelopes themselves.
+type Batch struct {
+	Items []PublishWrapper `json:"items,omitempty"`
+}
+
+// NewBatch returns the envelope for the given messages.
+func NewBatch(items []PublishWrapper) (PublishWrapper, error) {
+	b, err := json.Marshal(Batch{Items: items})
+	if err != nil {
+		return PublishWrapper{}, err
+	}
+	return PublishWrapper{Action: ActionBatch, MessageType: BatchType, Content: b}, nil
+}
+
+// Batch decodes the messages grouped by the wrapper when it is an envelope. The second return value is false for
+// other messages, or if the content cannot be decoded.
+func (w PublishWrapper) Batch() ([]PublishWrapper, bool) {
+	if w.Action != ActionBatch || w.MessageType != BatchType {
+		return nil, false
+	}
+	var batch Batch
+	if err := json.Unmarshal(w.Content, &batch); err != nil {
+		return nil, false
+	}
+	return batch.Items, true
+}
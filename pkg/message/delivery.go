@@ -0,0 +1,109 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+)
+
+// Delivery is a message received by a stream subscriber.
+type Delivery struct {
+	SubscribeWrapper
+	Annotations contracts.AnnotationList // Annotations holds the decoded Content when MessageType is AnnotationListType
+	Source      string                   // Source identifies where the message was read from, e.g. a topic or file
+	Sequence    uint64                   // Sequence is the position of the message within Source, if the stream assigns one
+	Timestamp   time.Time                // Timestamp is when the stream accepted the message, zero if unknown
+}
+
+// NewDelivery decodes a message as written by a stream provider, i.e. a JSON encoded PublishWrapper.
+func NewDelivery(payload []byte, source string) (Delivery, error) {
+	d := Delivery{Source: source}
+	err := json.Unmarshal(payload, &d.SubscribeWrapper)
+	if err != nil {
+		return Delivery{}, err
+	}
+	err = d.decode()
+	if err != nil {
+		return Delivery{}, err
+	}
+	return d, nil
+}
+
+// NewDeliveries decodes a message like NewDelivery, except that a Batch envelope is split into a delivery for each
+// message it holds, in order. Stream subscribers use it so that handlers never see envelopes.
+func NewDeliveries(payload []byte, source string) ([]Delivery, error) {
+	d, err := NewDelivery(payload, source)
+	if err != nil {
+		return nil, err
+	}
+	if d.Action != ActionBatch {
+		return []Delivery{d}, nil
+	}
+
+	if d.MessageType != BatchType {
+		return nil, fmt.Errorf("invalid batch MessageType value received %s", d.MessageType)
+	}
+	var batch Batch
+	err = json.Unmarshal(d.Content, &batch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode batch: %w", err)
+	}
+	deliveries := make([]Delivery, len(batch.Items))
+	for i, item := range batch.Items {
+		if item.Action == ActionBatch {
+			return nil, fmt.Errorf("batch item %d is a batch", i)
+		}
+		deliveries[i] = Delivery{SubscribeWrapper: SubscribeWrapper(item), Source: source}
+		err = deliveries[i].decode()
+		if err != nil {
+			return nil, fmt.Errorf("batch item %d: %w", i, err)
+		}
+	}
+	return deliveries, nil
+}
+
+// decode validates the wrapper and decodes its annotations
+func (d *Delivery) decode() error {
+	if !d.Action.validate() {
+		return fmt.Errorf("invalid SdkAction value received %s", d.Action)
+	}
+
+	if d.MessageType == AnnotationListType {
+		err := json.Unmarshal(d.Content, &d.Annotations)
+		if err != nil {
+			return fmt.Errorf("failed to decode annotation list: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeliveryError reports a message that a stream subscriber could not decode, or that was rejected by the handler.
+type DeliveryError struct {
+	Source  string // Source identifies where the message was read from
+	Payload []byte // Payload is the message as received
+	Err     error
+}
+
+func (e *DeliveryError) Error() string {
+	return fmt.Sprintf("delivery from %s failed: %v", e.Source, e.Err)
+}
+
+func (e *DeliveryError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,127 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestNewDelivery(t *testing.T) {
+	annotation := contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+	list := contracts.AnnotationList{Items: []contracts.Annotation{annotation}}
+	b, _ := json.Marshal(list)
+
+	pass, _ := json.Marshal(PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: b})
+	pass2, _ := json.Marshal(PublishWrapper{Action: ActionBroadcast, MessageType: "string", Content: []byte("topic")})
+	fail, _ := json.Marshal(PublishWrapper{Action: "invalid", MessageType: AnnotationListType, Content: b})
+	fail2, _ := json.Marshal(PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: []byte("{")})
+
+	tests := []struct {
+		name        string
+		payload     []byte
+		expectItems int
+		expectError bool
+	}{
+		{"annotation list", pass, 1, false},
+		{"broadcast", pass2, 0, false},
+		{"invalid action", fail, 0, true},
+		{"malformed content", fail2, 0, true},
+		{"malformed payload", []byte("not json"), 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDelivery(tt.payload, "source")
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			if d.Source != "source" || len(d.Annotations.Items) != tt.expectItems {
+				t.Errorf("unexpected delivery %v", d)
+			}
+			if tt.expectItems > 0 && d.Annotations.Items[0].Id != annotation.Id {
+				t.Errorf("unexpected annotation %v", d.Annotations.Items[0])
+			}
+		})
+	}
+
+	err := error(&DeliveryError{Source: "source", Err: errors.New("rejected")})
+	if err.Error() != "delivery from source failed: rejected" || errors.Unwrap(err).Error() != "rejected" {
+		t.Errorf("unexpected DeliveryError %v", err)
+	}
+}
+
+func TestNewDeliveries(t *testing.T) {
+	annotation := contracts.NewAnnotation("datakey", contracts.SHA256Hash, "host", contracts.Application, contracts.AnnotationTPM, true)
+	list := contracts.AnnotationList{Items: []contracts.Annotation{annotation}}
+	b, _ := json.Marshal(list)
+	create := PublishWrapper{Action: ActionCreate, MessageType: AnnotationListType, Content: b}
+	transit := PublishWrapper{Action: ActionTransit, MessageType: AnnotationListType, Content: b}
+
+	envelope, _ := NewBatch([]PublishWrapper{create, transit})
+	nested, _ := NewBatch([]PublishWrapper{create, envelope})
+	invalid, _ := NewBatch([]PublishWrapper{create, {Action: "invalid"}})
+
+	pass, _ := json.Marshal(create)
+	pass2, _ := json.Marshal(envelope)
+	fail, _ := json.Marshal(nested)
+	fail2, _ := json.Marshal(invalid)
+	fail3, _ := json.Marshal(PublishWrapper{Action: ActionBatch, MessageType: "string", Content: []byte("{}")})
+	fail4, _ := json.Marshal(PublishWrapper{Action: ActionBatch, MessageType: BatchType, Content: []byte("{")})
+
+	tests := []struct {
+		name          string
+		payload       []byte
+		expectActions []SdkAction
+		expectError   bool
+	}{
+		{"single message", pass, []SdkAction{ActionCreate}, false},
+		{"batch", pass2, []SdkAction{ActionCreate, ActionTransit}, false},
+		{"nested batch", fail, nil, true},
+		{"invalid item", fail2, nil, true},
+		{"invalid batch type", fail3, nil, true},
+		{"malformed batch", fail4, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deliveries, err := NewDeliveries(tt.payload, "source")
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				return
+			}
+			if len(deliveries) != len(tt.expectActions) {
+				t.Fatalf("expected %d deliveries, received %d", len(tt.expectActions), len(deliveries))
+			}
+			for i, d := range deliveries {
+				if d.Action != tt.expectActions[i] || d.Source != "source" || len(d.Annotations.Items) != 1 ||
+					d.Annotations.Items[0].Id != annotation.Id {
+					t.Errorf("unexpected delivery %d %v", i, d)
+				}
+			}
+		})
+	}
+
+	items, ok := envelope.Batch()
+	if !ok || len(items) != 2 || items[1].Action != ActionTransit {
+		t.Errorf("unexpected batch items %v", items)
+	}
+	if _, ok = create.Batch(); ok {
+		t.Errorf("expected a single message not to be a batch")
+	}
+}
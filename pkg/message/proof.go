@@ -0,0 +1,28 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package message
+
+import "time"
+
+// ConsensusProof ties a published message to the entry recorded for it by a ledger, such as a Hedera topic.
+type ConsensusProof struct {
+	TopicId            string    `json:"topicId"`
+	Sequence           uint64    `json:"sequence"`    // Sequence is the number of the message within the topic
+	RunningHash        []byte    `json:"runningHash"` // RunningHash of the topic after the message was added
+	RunningHashVersion uint64    `json:"runningHashVersion"`
+	Timestamp          time.Time `json:"timestamp,omitempty"` // Timestamp of consensus, zero if it was not fetched
+	TransactionId      string    `json:"transactionId"`
+	AnnotationIds      []string  `json:"annotationIds,omitempty"` // AnnotationIds carried by the message
+}
@@ -23,10 +23,12 @@ const (
 	ActionPublish   SdkAction = "publish"
 	ActionBroadcast SdkAction = "broadcast"
 	ActionEndStream SdkAction = "end-stream"
+	ActionBatch     SdkAction = "batch" // ActionBatch marks an envelope grouping several messages, see Batch
 )
 
 func (s SdkAction) validate() bool {
-	if s == ActionCreate || s == ActionMutate || s == ActionTransit || s == ActionPublish || s == ActionBroadcast || s == ActionEndStream {
+	if s == ActionCreate || s == ActionMutate || s == ActionTransit || s == ActionPublish || s == ActionBroadcast || s == ActionEndStream ||
+		s == ActionBatch {
 		return true
 	}
 	return false
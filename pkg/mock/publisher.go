@@ -0,0 +1,277 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+// Package mock provides a stream that records what is published to it, for testing code built on the SDK without a
+// real stream platform.
+package mock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// ErrInjected is returned for failures configured through MockStreamConfig.FailEvery or FailConnect
+var ErrInjected = errors.New("mock stream failure")
+
+// Streams collects the Publishers created by an SDK, keyed by StreamInfo.Name. This is how tests obtain the
+// publishers that the SDK created from its configuration: pass Add to pkg.WithStreamHook and call Lookup once the SDK
+// has been bootstrapped. The zero value is ready to use and safe for concurrent use.
+type Streams struct {
+	mutex      sync.Mutex
+	publishers map[string]*Publisher
+}
+
+// Add records stream under info.Name if it is a Publisher. Other streams are ignored.
+func (s *Streams) Add(info config.StreamInfo, stream interfaces.StreamProvider) {
+	p, ok := stream.(*Publisher)
+	if !ok {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.publishers == nil {
+		s.publishers = make(map[string]*Publisher)
+	}
+	s.publishers[info.Name] = p
+}
+
+// Lookup returns the Publisher added for the stream with the given name.
+func (s *Streams) Lookup(name string) (*Publisher, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	p, ok := s.publishers[name]
+	return p, ok
+}
+
+// Record is a message accepted by the Publisher
+type Record struct {
+	Wrapper     message.PublishWrapper
+	Annotations []contracts.Annotation // Decoded from the wrapper, or from the messages it groups if it is a batch envelope
+	Published   time.Time
+}
+
+// Publisher is a StreamProvider which records every message published to it. Failures and latency can be injected
+// through the configuration or the methods below. All methods are safe for concurrent use.
+type Publisher struct {
+	cfg    config.MockStreamConfig
+	logger interfaces.Logger
+
+	mutex     sync.Mutex // guards all fields below
+	connected bool
+	closed    bool
+	attempts  int
+	latency   time.Duration
+	failures  []error
+	records   []Record
+	changed   chan struct{} // closed and replaced whenever a message is recorded
+}
+
+// NewMockPublisher returns a Publisher that has not been connected yet.
+func NewMockPublisher(cfg config.MockStreamConfig, logger interfaces.Logger) *Publisher {
+	return &Publisher{
+		cfg:     cfg,
+		logger:  logger,
+		latency: time.Millisecond * time.Duration(cfg.Latency),
+		changed: make(chan struct{}),
+	}
+}
+
+func (p *Publisher) Connect() error {
+	if p.cfg.FailConnect {
+		return fmt.Errorf("failed to connect: %w", ErrInjected)
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.connected = true
+	p.closed = false
+	return nil
+}
+
+// Publish records the message after the configured latency, unless a failure is due.
+func (p *Publisher) Publish(msg message.PublishWrapper) error {
+	p.mutex.Lock()
+	latency := p.latency
+	p.attempts++
+	var err error
+	if len(p.failures) > 0 {
+		err = p.failures[0]
+		p.failures = p.failures[1:]
+	} else if p.cfg.FailEvery > 0 && p.attempts%p.cfg.FailEvery == 0 {
+		err = fmt.Errorf("publish %d failed: %w", p.attempts, ErrInjected)
+	}
+	p.mutex.Unlock()
+
+	if latency > 0 {
+		time.Sleep(latency)
+	}
+	if err != nil {
+		return err
+	}
+
+	r := Record{Wrapper: msg, Published: time.Now(), Annotations: msg.Annotations()}
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.records = append(p.records, r)
+	close(p.changed)
+	p.changed = make(chan struct{})
+	return nil
+}
+
+// PublishWithProof publishes like Publish and returns a proof of the message, so that the Publisher can stand in for
+// an interfaces.ProofProvider. The proof names the mock stream type as topic and carries the number of messages recorded as
+// sequence number.
+func (p *Publisher) PublishWithProof(msg message.PublishWrapper) ([]message.ConsensusProof, error) {
+	err := p.Publish(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	proof := message.ConsensusProof{TopicId: string(contracts.MockStream), Timestamp: time.Now()}
+	for _, a := range msg.Annotations() {
+		proof.AnnotationIds = append(proof.AnnotationIds, a.Id.String())
+	}
+	p.mutex.Lock()
+	proof.Sequence = uint64(len(p.records))
+	p.mutex.Unlock()
+	return []message.ConsensusProof{proof}, nil
+}
+
+func (p *Publisher) Close() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.connected = false
+	p.closed = true
+	return nil
+}
+
+// FailNext makes the next n publishes fail with err. It adds to failures already pending.
+func (p *Publisher) FailNext(n int, err error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	for i := 0; i < n; i++ {
+		p.failures = append(p.failures, err)
+	}
+}
+
+// SetLatency changes the time each publish takes
+func (p *Publisher) SetLatency(latency time.Duration) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.latency = latency
+}
+
+// Connected reports whether Connect has succeeded and Close has not been called since
+func (p *Publisher) Connected() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.connected
+}
+
+// Closed reports whether Close has been called
+func (p *Publisher) Closed() bool {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.closed
+}
+
+// Attempts returns the number of publishes, including those that failed
+func (p *Publisher) Attempts() int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return p.attempts
+}
+
+// Records returns the messages published so far, in the order they were accepted
+func (p *Publisher) Records() []Record {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]Record(nil), p.records...)
+}
+
+// Annotations returns the annotations carried by all messages published so far
+func (p *Publisher) Annotations() []contracts.Annotation {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return annotations(p.records)
+}
+
+// Reset discards the recorded messages and pending failures
+func (p *Publisher) Reset() {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.records = nil
+	p.failures = nil
+	p.attempts = 0
+}
+
+// WaitForMessages blocks until at least n messages have been published and returns them, or returns the error of
+// ctx if it ends first.
+func (p *Publisher) WaitForMessages(ctx context.Context, n int) ([]Record, error) {
+	var records []Record
+	err := p.wait(ctx, func() bool {
+		if len(p.records) < n {
+			return false
+		}
+		records = append([]Record(nil), p.records...)
+		return true
+	})
+	return records, err
+}
+
+// WaitForAnnotations blocks until messages carrying at least n annotations have been published and returns the
+// annotations, or returns the error of ctx if it ends first.
+func (p *Publisher) WaitForAnnotations(ctx context.Context, n int) ([]contracts.Annotation, error) {
+	var items []contracts.Annotation
+	err := p.wait(ctx, func() bool {
+		items = annotations(p.records)
+		return len(items) >= n
+	})
+	return items, err
+}
+
+// wait calls done with the mutex held each time a message is recorded, until it returns true
+func (p *Publisher) wait(ctx context.Context, done func() bool) error {
+	for {
+		p.mutex.Lock()
+		if done() {
+			p.mutex.Unlock()
+			return nil
+		}
+		changed := p.changed
+		p.mutex.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+func annotations(records []Record) []contracts.Annotation {
+	var items []contracts.Annotation
+	for _, r := range records {
+		items = append(items, r.Annotations...)
+	}
+	return items
+}
@@ -0,0 +1,174 @@
+/*******************************************************************************
+ * Copyright 2023 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package mock
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/testutil"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/logging"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
+)
+
+func TestMockPublisher(t *testing.T) {
+	injected := errors.New("broker unavailable")
+
+	tests := []struct {
+		name              string
+		cfg               config.MockStreamConfig
+		failNext          int
+		publishes         int
+		expectConnect     bool // whether Connect is expected to fail
+		expectFailed      int
+		expectAnnotations int
+	}{
+		{"records", config.MockStreamConfig{}, 0, 3, false, 0, 6},
+		{"fail every", config.MockStreamConfig{FailEvery: 2}, 0, 5, false, 2, 6},
+		{"fail next", config.MockStreamConfig{}, 2, 3, false, 2, 2},
+		{"fail connect", config.MockStreamConfig{FailConnect: true}, 0, 0, true, 0, 0},
+		{"latency", config.MockStreamConfig{Latency: 20}, 0, 1, false, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+			p := NewMockPublisher(tt.cfg, logger)
+			err := p.Connect()
+			test.CheckError(err, tt.expectConnect, tt.name, t)
+			if err != nil {
+				if !errors.Is(err, ErrInjected) || p.Connected() {
+					t.Errorf("unexpected connect failure %v", err)
+				}
+				return
+			}
+			p.FailNext(tt.failNext, injected)
+
+			failed := 0
+			start := time.Now()
+			for i := 0; i < tt.publishes; i++ {
+				err = p.Publish(testutil.AnnotationMessage(testutil.Keys(2)...))
+				if err != nil {
+					failed++
+					if !errors.Is(err, injected) && !errors.Is(err, ErrInjected) {
+						t.Errorf("unexpected publish failure %v", err)
+					}
+				}
+			}
+			if elapsed := time.Since(start); elapsed < time.Duration(tt.cfg.Latency*tt.publishes)*time.Millisecond {
+				t.Errorf("publishes took %s, less than the configured latency", elapsed)
+			}
+
+			records := p.Records()
+			if failed != tt.expectFailed || p.Attempts() != tt.publishes || len(records) != tt.publishes-tt.expectFailed {
+				t.Errorf("unexpected %d failed, %d attempts, %d recorded", failed, p.Attempts(), len(records))
+			}
+			items := p.Annotations()
+			if len(items) != tt.expectAnnotations {
+				t.Fatalf("unexpected %d annotations", len(items))
+			}
+			for _, item := range items {
+				if item.Kind != contracts.AnnotationTPM || !item.IsSatisfied {
+					t.Errorf("unexpected annotation %v", item)
+				}
+			}
+
+			err = p.Close()
+			if err != nil || !p.Closed() || p.Connected() {
+				t.Errorf("unexpected state after close %v", err)
+			}
+		})
+	}
+}
+
+func TestMockPublisherBatch(t *testing.T) {
+	logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	p := NewMockPublisher(config.MockStreamConfig{}, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	envelope, err := message.NewBatch([]message.PublishWrapper{testutil.AnnotationMessage(testutil.Keys(2)...), testutil.AnnotationMessage(testutil.Keys(1)...)})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err = p.Publish(envelope); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	items, err := p.WaitForAnnotations(ctx, 3)
+	if err != nil || len(items) != 3 || len(p.Records()[0].Annotations) != 3 {
+		t.Errorf("expected the annotations of the batched messages to be recorded, received %v %v", items, err)
+	}
+}
+
+func TestMockPublisherWait(t *testing.T) {
+	tests := []struct {
+		name        string
+		publishes   int
+		messages    int
+		annotations int
+		expectError bool
+	}{
+		{"messages", 3, 3, 0, false},
+		{"annotations", 2, 0, 4, false},
+		{"already published", 0, 0, 0, false},
+		{"timeout", 1, 2, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logging.NewConsoleLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+			p := NewMockPublisher(config.MockStreamConfig{}, logger)
+
+			publishes := tt.publishes
+			go func() {
+				for i := 0; i < publishes; i++ {
+					time.Sleep(10 * time.Millisecond)
+					_ = p.Publish(testutil.AnnotationMessage(testutil.Keys(2)...))
+				}
+			}()
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			if tt.expectError {
+				ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+				defer cancel()
+			}
+			records, err := p.WaitForMessages(ctx, tt.messages)
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+			if len(records) < tt.messages {
+				t.Errorf("unexpected %d messages", len(records))
+			}
+
+			items, err := p.WaitForAnnotations(ctx, tt.annotations)
+			if err != nil || len(items) < tt.annotations {
+				t.Errorf("unexpected %d annotations %v", len(items), err)
+			}
+		})
+	}
+}
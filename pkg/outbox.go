@@ -0,0 +1,315 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+const (
+	defaultRetryInterval    int = 500
+	defaultMaxRetryInterval int = 30000
+	defaultMaxAttempts      int = 5
+
+	outboxExt     = ".json"
+	outboxBadExt  = ".corrupt"
+	outboxDeadExt = ".failed"
+)
+
+// outboxStream decorates a StreamProvider with a write-ahead outbox on the local filesystem. Each message is
+// written to its own file, named by a monotonically increasing sequence number, before Publish returns. A
+// background worker delivers pending files to the wrapped stream in sequence order, retrying with exponential
+// backoff, and deletes each file only once the wrapped stream has accepted it. Files left behind by a previous
+// process are delivered after the next Connect.
+//
+// A message that keeps failing while the wrapped stream accepts others must be rejected for good, so after
+// MaxAttempts failures the next message is tried in its place. If that one is accepted, the failing message is set
+// aside as dead letter rather than blocking the outbox. Otherwise the wrapped stream is assumed to be unavailable.
+type outboxStream struct {
+	cfg    config.OutboxInfo
+	stream interfaces.StreamProvider
+	logger interfaces.Logger
+
+	mutex     sync.Mutex // guards sequence and closed
+	sequence  uint64
+	closed    bool
+	connected bool // only accessed by the worker after Connect
+	notify    chan struct{}
+	stop      chan struct{}
+	worker    sync.WaitGroup
+}
+
+func newOutboxStream(cfg config.OutboxInfo, stream interfaces.StreamProvider, logger interfaces.Logger) *outboxStream {
+	if cfg.RetryInterval == 0 {
+		cfg.RetryInterval = defaultRetryInterval
+	}
+	if cfg.MaxRetryInterval == 0 {
+		cfg.MaxRetryInterval = defaultMaxRetryInterval
+	}
+	if cfg.MaxAttempts == 0 {
+		cfg.MaxAttempts = defaultMaxAttempts
+	}
+
+	return &outboxStream{
+		cfg:    cfg,
+		stream: stream,
+		logger: logger,
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+}
+
+// Connect prepares the outbox directory and starts the delivery worker. A failure to connect the wrapped stream is
+// not fatal since the worker will keep retrying; messages accumulate in the outbox until then.
+func (p *outboxStream) Connect() error {
+	err := os.MkdirAll(p.cfg.Path, 0700)
+	if err != nil {
+		return err
+	}
+	err = p.removeIncomplete()
+	if err != nil {
+		return err
+	}
+
+	pending, err := p.pending()
+	if err != nil {
+		return err
+	}
+	if len(pending) > 0 {
+		p.sequence = pending[len(pending)-1]
+		p.logger.Write(slog.LevelInfo, fmt.Sprintf("outbox contains %d undelivered messages", len(pending)))
+	}
+
+	err = p.stream.Connect()
+	if err != nil {
+		p.logger.Write(slog.LevelWarn, fmt.Sprintf("stream unavailable, messages will be held in outbox: %s", err.Error()))
+	} else {
+		p.connected = true
+	}
+
+	p.worker.Add(1)
+	go p.deliver()
+	p.signal()
+	return nil
+}
+
+// Publish durably persists the message to the outbox. Delivery to the wrapped stream happens in the background.
+func (p *outboxStream) Publish(msg message.PublishWrapper) error {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return ErrStreamClosed
+	}
+	p.sequence++
+	seq := p.sequence
+	// Hold the lock while writing so that files become visible to the worker in sequence order
+	err = p.write(seq, b)
+	p.mutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to persist message to outbox: %w", err)
+	}
+
+	p.signal()
+	return nil
+}
+
+// Close stops the delivery worker and closes the wrapped stream. Undelivered messages remain in the outbox.
+func (p *outboxStream) Close() error {
+	p.mutex.Lock()
+	if p.closed {
+		p.mutex.Unlock()
+		return nil
+	}
+	p.closed = true
+	p.mutex.Unlock()
+
+	close(p.stop)
+	p.worker.Wait()
+	return p.stream.Close()
+}
+
+func (p *outboxStream) signal() {
+	select {
+	case p.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (p *outboxStream) deliver() {
+	defer p.worker.Done()
+
+	backoff := p.cfg.RetryInterval
+	attempts := 0 // failed deliveries of the first pending message
+	for {
+		pending, err := p.pending()
+		if err != nil {
+			p.logger.Error(fmt.Sprintf("failed to read outbox: %s", err.Error()))
+		}
+
+		for len(pending) > 0 {
+			err = p.send(pending[0])
+			if err == nil {
+				pending = pending[1:]
+				backoff = p.cfg.RetryInterval
+				attempts = 0
+				continue
+			}
+
+			attempts++
+			if attempts >= p.cfg.MaxAttempts {
+				attempts = 0
+				// Messages published since the outbox was last read may serve to tell the failures apart
+				if latest, readErr := p.pending(); readErr == nil {
+					pending = latest
+				}
+				if len(pending) > 1 && p.send(pending[1]) == nil {
+					p.deadLetter(pending[0], err)
+					pending = pending[2:]
+					backoff = p.cfg.RetryInterval
+					continue
+				}
+			}
+
+			p.logger.Error(fmt.Sprintf("outbox delivery failed, retrying in %dms: %s", backoff, err.Error()))
+			select {
+			case <-p.stop:
+				return
+			case <-time.After(time.Millisecond * time.Duration(backoff)):
+			}
+			backoff *= 2
+			if backoff > p.cfg.MaxRetryInterval {
+				backoff = p.cfg.MaxRetryInterval
+			}
+		}
+
+		select {
+		case <-p.stop:
+			return
+		case <-p.notify:
+		}
+	}
+}
+
+// send publishes a single outbox entry and removes it once the wrapped stream has accepted it.
+func (p *outboxStream) send(seq uint64) error {
+	path := p.filename(seq, outboxExt)
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	var msg message.PublishWrapper
+	err = json.Unmarshal(b, &msg)
+	if err != nil {
+		// Retrying will never succeed, so set the entry aside rather than blocking the rest of the outbox
+		p.logger.Error(fmt.Sprintf("corrupt outbox entry %d set aside: %s", seq, err.Error()))
+		return os.Rename(path, p.filename(seq, outboxBadExt))
+	}
+
+	if !p.connected {
+		err = p.stream.Connect()
+		if err != nil {
+			return err
+		}
+		p.connected = true
+	}
+
+	err = p.stream.Publish(msg)
+	if err != nil {
+		return err
+	}
+	return os.Remove(path)
+}
+
+// deadLetter sets aside an entry which the wrapped stream keeps rejecting.
+func (p *outboxStream) deadLetter(seq uint64, cause error) {
+	p.logger.Error(fmt.Sprintf("outbox entry %d rejected %d times while later entries were accepted, set aside: %s",
+		seq, p.cfg.MaxAttempts, cause.Error()))
+	err := os.Rename(p.filename(seq, outboxExt), p.filename(seq, outboxDeadExt))
+	if err != nil {
+		p.logger.Error(fmt.Sprintf("failed to set aside outbox entry %d: %s", seq, err.Error()))
+	}
+}
+
+// removeIncomplete removes files left behind by writes interrupted by a crash, which were never acknowledged.
+func (p *outboxStream) removeIncomplete() error {
+	entries, err := os.ReadDir(p.cfg.Path)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), atomicfile.TempExt) {
+			continue
+		}
+		p.logger.Write(slog.LevelWarn, fmt.Sprintf("removing incomplete outbox entry %s", e.Name()))
+		err = os.Remove(filepath.Join(p.cfg.Path, e.Name()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write persists the content under the given sequence number. The file is synced and renamed into place so that
+// a crash never leaves a partially written entry behind.
+func (p *outboxStream) write(seq uint64, content []byte) error {
+	return atomicfile.Write(p.filename(seq, outboxExt), content, 0600)
+}
+
+// pending returns the sequence numbers of all undelivered entries in ascending order.
+func (p *outboxStream) pending() ([]uint64, error) {
+	entries, err := os.ReadDir(p.cfg.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	var seqs []uint64
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, outboxExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, outboxExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	return seqs, nil
+}
+
+func (p *outboxStream) filename(seq uint64, ext string) string {
+	return filepath.Join(p.cfg.Path, fmt.Sprintf("%020d%s", seq, ext))
+}
@@ -0,0 +1,188 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/project-alvarium/alvarium-sdk-go/internal/atomicfile"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/config"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// flakyStream rejects publishes until its failures budget is exhausted, as well as any message with the content
+// reject, and records the ones it accepts.
+type flakyStream struct {
+	mutex     sync.Mutex
+	failures  int
+	reject    string
+	published []string
+}
+
+func (p *flakyStream) Connect() error {
+	return nil
+}
+
+func (p *flakyStream) Publish(msg message.PublishWrapper) error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if p.failures > 0 {
+		p.failures--
+		return errors.New("stream unavailable")
+	}
+	if p.reject != "" && string(msg.Content) == p.reject {
+		return errors.New("message rejected")
+	}
+	p.published = append(p.published, string(msg.Content))
+	return nil
+}
+
+func (p *flakyStream) Close() error {
+	return nil
+}
+
+func (p *flakyStream) contents() []string {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	return append([]string{}, p.published...)
+}
+
+func waitFor(t *testing.T, condition func() bool) {
+	deadline := time.Now().Add(2 * time.Second)
+	for !condition() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met before deadline")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestOutboxStreamRetry(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.OutboxInfo{Enabled: true, Path: t.TempDir(), RetryInterval: 5, MaxRetryInterval: 20}
+	stream := &flakyStream{failures: 3}
+
+	p := newOutboxStream(cfg, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, content := range []string{"a", "b", "c"} {
+		if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate, Content: []byte(content)}); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	waitFor(t, func() bool { return len(stream.contents()) == 3 })
+	if err := p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	published := stream.contents()
+	if published[0] != "a" || published[1] != "b" || published[2] != "c" {
+		t.Errorf("unexpected delivery order %v", published)
+	}
+	entries, _ := os.ReadDir(cfg.Path)
+	if len(entries) != 0 {
+		t.Errorf("expected empty outbox after delivery, found %d entries", len(entries))
+	}
+}
+
+func TestOutboxStreamRestart(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.OutboxInfo{Enabled: true, Path: t.TempDir(), RetryInterval: 5}
+
+	// The first process never manages to deliver
+	down := &flakyStream{failures: 1000}
+	p := newOutboxStream(cfg, down, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	for _, content := range []string{"a", "b"} {
+		if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate, Content: []byte(content)}); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+	if err := p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate}); !errors.Is(err, ErrStreamClosed) {
+		t.Errorf("expected ErrStreamClosed, received %v", err)
+	}
+
+	// The second process delivers what was left behind, followed by its own messages
+	up := &flakyStream{}
+	p = newOutboxStream(cfg, up, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate, Content: []byte("c")}); err != nil {
+		t.Fatalf(err.Error())
+	}
+	waitFor(t, func() bool { return len(up.contents()) == 3 })
+	if err := p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	published := up.contents()
+	if published[0] != "a" || published[1] != "b" || published[2] != "c" {
+		t.Errorf("unexpected delivery order %v", published)
+	}
+}
+
+func TestOutboxStreamDeadLetter(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	cfg := config.OutboxInfo{Enabled: true, Path: t.TempDir(), RetryInterval: 5, MaxRetryInterval: 5, MaxAttempts: 2}
+	// An interrupted write left behind by a previous process
+	incomplete := filepath.Join(cfg.Path, fmt.Sprintf("%020d%s%s", 7, outboxExt, atomicfile.TempExt))
+	if err := os.WriteFile(incomplete, []byte("{"), 0600); err != nil {
+		t.Fatalf(err.Error())
+	}
+	stream := &flakyStream{reject: "a"}
+
+	p := newOutboxStream(cfg, stream, logger)
+	if err := p.Connect(); err != nil {
+		t.Fatalf(err.Error())
+	}
+	if _, err := os.Stat(incomplete); !os.IsNotExist(err) {
+		t.Errorf("expected incomplete entry to be removed, received %v", err)
+	}
+	for _, content := range []string{"a", "b", "c"} {
+		if err := p.Publish(message.PublishWrapper{Action: message.ActionCreate, Content: []byte(content)}); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+
+	waitFor(t, func() bool { return len(stream.contents()) == 2 })
+	if err := p.Close(); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	published := stream.contents()
+	if published[0] != "b" || published[1] != "c" {
+		t.Errorf("unexpected delivery order %v", published)
+	}
+	entries, _ := os.ReadDir(cfg.Path)
+	if len(entries) != 1 || entries[0].Name() != fmt.Sprintf("%020d%s", 1, outboxDeadExt) {
+		t.Errorf("expected only the rejected entry to remain, set aside, found %v", entries)
+	}
+}
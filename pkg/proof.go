@@ -0,0 +1,55 @@
+/*******************************************************************************
+ * Copyright 2024 Dell Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package pkg
+
+import (
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+)
+
+// proofStream decorates a StreamProvider, handing the proofs of every message published to a ProofHandler. Messages
+// are published with PublishWithProof when the wrapped stream implements interfaces.ProofProvider, and with Publish
+// otherwise. It sits directly on top of the configured stream so that the handler is called whichever decorators
+// are layered above it.
+type proofStream struct {
+	stream  interfaces.StreamProvider
+	handler interfaces.ProofHandler
+}
+
+func newProofStream(stream interfaces.StreamProvider, handler interfaces.ProofHandler) *proofStream {
+	return &proofStream{stream: stream, handler: handler}
+}
+
+func (p *proofStream) Connect() error {
+	return p.stream.Connect()
+}
+
+// Publish publishes the message and calls the handler with any proofs returned, including those for the messages
+// submitted before a failure.
+func (p *proofStream) Publish(msg message.PublishWrapper) error {
+	prover, ok := p.stream.(interfaces.ProofProvider)
+	if !ok {
+		return p.stream.Publish(msg)
+	}
+	proofs, err := prover.PublishWithProof(msg)
+	if len(proofs) > 0 {
+		p.handler(msg, proofs)
+	}
+	return err
+}
+
+func (p *proofStream) Close() error {
+	return p.stream.Close()
+}
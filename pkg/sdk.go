@@ -16,6 +16,7 @@ package pkg
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -32,23 +33,83 @@ type sdk struct {
 	cfg        config.SdkInfo
 	stream     interfaces.StreamProvider
 	logger     interfaces.Logger
+	onProof    interfaces.ProofHandler
+	onStream   func(config.StreamInfo, interfaces.StreamProvider)
 }
 
-func NewSdk(annotators []interfaces.Annotator, cfg config.SdkInfo, logger interfaces.Logger) interfaces.Sdk {
+// SdkOption customizes the Sdk returned by NewSdk.
+type SdkOption func(*sdk)
+
+// WithProofHandler hands the consensus proofs of published annotations to handler. Streams that record messages on a
+// ledger, such as Hedera, then wait for consensus on every publish. In async, batch or outbox mode the handler is
+// called from the goroutine publishing in the background, with the envelope as message when batching.
+func WithProofHandler(handler interfaces.ProofHandler) SdkOption {
+	return func(s *sdk) {
+		s.onProof = handler
+	}
+}
+
+// WithStreamHook calls hook with every stream created by BootstrapHandler, before it is connected, along with the
+// configuration it was created from. With a fanout each of the configured streams is passed individually. Tests use it
+// to reach the recorders behind mock streams, see mock.Streams.
+func WithStreamHook(hook func(info config.StreamInfo, stream interfaces.StreamProvider)) SdkOption {
+	return func(s *sdk) {
+		s.onStream = hook
+	}
+}
+
+func NewSdk(annotators []interfaces.Annotator, cfg config.SdkInfo, logger interfaces.Logger,
+	opts ...SdkOption) interfaces.Sdk {
 	instance := sdk{
 		annotators: annotators,
 		cfg:        cfg,
 		logger:     logger,
 	}
+	for _, opt := range opts {
+		opt(&instance)
+	}
 	return &instance
 }
 
 func (s *sdk) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup) bool {
-	stream, err := factories.NewStreamProvider(s.cfg.Stream, s.logger)
+	err := s.cfg.ValidateBatching()
 	if err != nil {
 		s.logger.Error(err.Error())
 		return false
 	}
+
+	var stream interfaces.StreamProvider
+	if len(s.cfg.Streams) > 0 {
+		var fanout *fanoutStream
+		fanout, err = newFanoutStream(s.cfg.Fanout, s.cfg.Streams, s.logger)
+		if err == nil && s.onStream != nil {
+			for _, m := range fanout.members {
+				s.onStream(s.cfg.Streams[m.index], m.stream)
+			}
+		}
+		stream = fanout
+	} else {
+		stream, err = factories.NewStreamProvider(s.cfg.Stream, s.logger)
+		if err == nil && s.onStream != nil {
+			s.onStream(s.cfg.Stream, stream)
+		}
+	}
+	if err != nil {
+		s.logger.Error(err.Error())
+		return false
+	}
+	if s.onProof != nil {
+		stream = newProofStream(stream, s.onProof)
+	}
+	if s.cfg.Outbox.Enabled {
+		stream = newOutboxStream(s.cfg.Outbox, stream, s.logger)
+	}
+	if s.cfg.Batch.Enabled {
+		stream = newBatchStream(s.cfg.Batch, stream, s.logger)
+	}
+	if s.cfg.Async.Enabled {
+		stream = newAsyncStream(s.cfg.Async, stream, s.logger)
+	}
 	s.stream = stream
 	//Connect to stream provider
 	err = s.stream.Connect()
@@ -64,113 +125,122 @@ func (s *sdk) BootstrapHandler(ctx context.Context, wg *sync.WaitGroup) bool {
 
 		<-ctx.Done()
 		s.logger.Write(slog.LevelInfo, "shutdown received")
-		s.stream.Close()
+		// In async or batch mode this also flushes annotations still waiting to be published
+		err := s.stream.Close()
+		if err != nil {
+			s.logger.Error(err.Error())
+		}
 	}()
 	return true
 }
 
 func (s *sdk) Create(ctx context.Context, data []byte) {
-	var list contracts.AnnotationList
-
-	for _, a := range s.annotators {
-		annotation, err := a.Do(ctx, data)
-		if err != nil {
-			s.logger.Error(err.Error())
-			return
-		}
-		list.Items = append(list.Items, annotation)
+	if err := s.TryCreate(ctx, data); err != nil {
+		s.logger.Error(err.Error())
 	}
+}
 
-	b, _ := json.Marshal(list)
-	wrap := message.PublishWrapper{
-		Action:      message.ActionCreate,
-		MessageType: fmt.Sprintf("%T", list),
-		Content:     b,
-	}
-	err := s.stream.Publish(wrap)
+func (s *sdk) TryCreate(ctx context.Context, data []byte) error {
+	list, partial, err := s.annotate(ctx, message.ActionCreate, data)
 	if err != nil {
-		s.logger.Error(err.Error())
+		return err
 	}
+	return s.publish(message.ActionCreate, list, partial)
 }
 
 func (s *sdk) Mutate(ctx context.Context, old, new []byte) {
+	if err := s.TryMutate(ctx, old, new); err != nil {
+		s.logger.Error(err.Error())
+	}
+}
+
+func (s *sdk) TryMutate(ctx context.Context, old, new []byte) error {
 	src, err := factories.NewAnnotator(contracts.AnnotationSource, s.cfg)
 	if err != nil {
-		s.logger.Error(err.Error())
-		return
+		return err
 	}
 	a, err := src.Do(ctx, old)
+	if err != nil {
+		return &AnnotatorError{Action: message.ActionMutate, Index: -1, Annotator: fmt.Sprintf("%T", src), Err: err}
+	}
 
-	var list contracts.AnnotationList
-	list.Items = append(list.Items, a)
+	list, partial, err := s.annotate(ctx, message.ActionMutate, new)
+	if err != nil {
+		return err
+	}
 
-	for _, a := range s.annotators {
-		annotation, err := a.Do(ctx, new)
-		if err != nil {
-			s.logger.Error(err.Error())
-			return
-		}
+	items := []contracts.Annotation{a}
+	for _, annotation := range list.Items {
 		if annotation.Kind != contracts.AnnotationTLS {
-			list.Items = append(list.Items, annotation)
+			items = append(items, annotation)
 		}
 	}
+	list.Items = items
+	return s.publish(message.ActionMutate, list, partial)
+}
 
-	b, _ := json.Marshal(list)
-	wrap := message.PublishWrapper{
-		Action:      message.ActionMutate,
-		MessageType: fmt.Sprintf("%T", list),
-		Content:     b,
-	}
-	err = s.stream.Publish(wrap)
-	if err != nil {
+func (s *sdk) Transit(ctx context.Context, data []byte) {
+	if err := s.TryTransit(ctx, data); err != nil {
 		s.logger.Error(err.Error())
 	}
 }
 
-func (s *sdk) Transit(ctx context.Context, data []byte) {
-	var list contracts.AnnotationList
-
-	for _, a := range s.annotators {
-		annotation, err := a.Do(ctx, data)
-		if err != nil {
-			s.logger.Error(err.Error())
-			return
-		}
-		list.Items = append(list.Items, annotation)
+func (s *sdk) TryTransit(ctx context.Context, data []byte) error {
+	list, partial, err := s.annotate(ctx, message.ActionTransit, data)
+	if err != nil {
+		return err
 	}
+	return s.publish(message.ActionTransit, list, partial)
+}
 
-	b, _ := json.Marshal(list)
-	wrap := message.PublishWrapper{
-		Action:      message.ActionTransit,
-		MessageType: fmt.Sprintf("%T", list),
-		Content:     b,
-	}
-	err := s.stream.Publish(wrap)
-	if err != nil {
+func (s *sdk) Publish(ctx context.Context, data []byte) {
+	if err := s.TryPublish(ctx, data); err != nil {
 		s.logger.Error(err.Error())
 	}
 }
 
-func (s *sdk) Publish(ctx context.Context, data []byte) {
-	var list contracts.AnnotationList
+func (s *sdk) TryPublish(ctx context.Context, data []byte) error {
+	list, partial, err := s.annotate(ctx, message.ActionPublish, data)
+	if err != nil {
+		return err
+	}
+	return s.publish(message.ActionPublish, list, partial)
+}
 
-	for _, a := range s.annotators {
-		annotation, err := a.Do(ctx, data)
-		if err != nil {
-			s.logger.Error(err.Error())
-			return
-		}
-		list.Items = append(list.Items, annotation)
+// publish wraps the annotation list for the given action and hands it to the stream provider. If annotation was
+// only partially successful, the partial error is returned once the remaining annotations have been published.
+func (s *sdk) publish(action message.SdkAction, list contracts.AnnotationList, partial *PartialAnnotationError) error {
+	if s.stream == nil {
+		return ErrNotBootstrapped
+	}
+	if partial != nil && len(list.Items) == 0 {
+		return partial
 	}
 
-	b, _ := json.Marshal(list)
+	b, err := json.Marshal(list)
+	if err != nil {
+		return fmt.Errorf("%s: failed to marshal annotation list: %w", action, err)
+	}
 	wrap := message.PublishWrapper{
-		Action:      message.ActionPublish,
+		Action:      action,
 		MessageType: fmt.Sprintf("%T", list),
 		Content:     b,
 	}
-	err := s.stream.Publish(wrap)
+	err = s.stream.Publish(wrap)
 	if err != nil {
-		s.logger.Error(err.Error())
+		// A fan-out failure already identifies each stream that rejected the annotations
+		var fanout *FanoutError
+		if errors.As(err, &fanout) {
+			return err
+		}
+		// Otherwise a failure with several streams, such as publishing after shutdown, concerns them all
+		if len(s.cfg.Streams) > 0 {
+			return &StreamError{Action: action, Index: -1, Err: err}
+		}
+		return &StreamError{Action: action, Index: -1, Name: s.cfg.Stream.Name, Stream: s.cfg.Stream.Type, Err: err}
+	}
+	if partial != nil {
+		return partial
 	}
+	return nil
 }
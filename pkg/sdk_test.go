@@ -16,10 +16,13 @@ package pkg
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"gopkg.in/yaml.v3"
 
@@ -27,6 +30,9 @@ import (
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/contracts"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/factories"
 	"github.com/project-alvarium/alvarium-sdk-go/pkg/interfaces"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/message"
+	"github.com/project-alvarium/alvarium-sdk-go/pkg/mock"
+	"github.com/project-alvarium/alvarium-sdk-go/test"
 )
 
 func TestNewSdkJson(t *testing.T) {
@@ -128,3 +134,491 @@ func TestNewSdkYaml(t *testing.T) {
 		})
 	}
 }
+
+type failingAnnotator struct {
+	err error
+}
+
+func (a failingAnnotator) Do(ctx context.Context, data []byte) (contracts.Annotation, error) {
+	return contracts.Annotation{}, a.err
+}
+
+type failingStream struct {
+	err error
+}
+
+func (p failingStream) Connect() error {
+	return nil
+}
+
+func (p failingStream) Publish(msg message.PublishWrapper) error {
+	return p.err
+}
+
+func (p failingStream) Close() error {
+	return nil
+}
+
+func TestSdkTryCreateErrors(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	b, err := os.ReadFile("../test/res/config.json")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	var cfg config.SdkInfo
+	err = json.Unmarshal(b, &cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// Paths in the shared config are relative to packages two levels deep
+	cfg.Signature.PrivateKey.Path = "../test/keys/ed25519/private.key"
+	cfg.Signature.PublicKey.Path = "../test/keys/ed25519/public.key"
+
+	tpm, err := factories.NewAnnotator(contracts.AnnotationTPM, cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	annotatorErr := errors.New("annotator failure")
+	streamErr := errors.New("stream failure")
+
+	tests := []struct {
+		name            string
+		annotators      []interfaces.Annotator
+		stream          interfaces.StreamProvider
+		expectAnnotator bool
+		expectStream    bool
+	}{
+		{"successful create", []interfaces.Annotator{tpm}, failingStream{}, false, false},
+		{"annotator failure", []interfaces.Annotator{tpm, failingAnnotator{err: annotatorErr}}, failingStream{}, true, false},
+		{"stream failure", []interfaces.Annotator{tpm}, failingStream{err: streamErr}, false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			instance := sdk{
+				annotators: tt.annotators,
+				cfg:        cfg,
+				stream:     tt.stream,
+				logger:     logger,
+			}
+
+			err := instance.TryCreate(context.Background(), []byte("data"))
+			test.CheckError(err, tt.expectAnnotator || tt.expectStream, tt.name, t)
+
+			var ae *AnnotatorError
+			if errors.As(err, &ae) != tt.expectAnnotator {
+				t.Errorf("unexpected AnnotatorError result: %v", err)
+			}
+			if tt.expectAnnotator && (ae.Index != 1 || !errors.Is(err, annotatorErr)) {
+				t.Errorf("unexpected AnnotatorError content: %v", ae)
+			}
+
+			var se *StreamError
+			if errors.As(err, &se) != tt.expectStream {
+				t.Errorf("unexpected StreamError result: %v", err)
+			}
+			if tt.expectStream && (se.Stream != cfg.Stream.Type || se.Index != -1 || !errors.Is(err, streamErr)) {
+				t.Errorf("unexpected StreamError content: %v", se)
+			}
+		})
+	}
+}
+
+func TestSdkNotBootstrapped(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	instance := NewSdk(nil, config.SdkInfo{}, logger)
+
+	err := instance.TryTransit(context.Background(), []byte("data"))
+	if !errors.Is(err, ErrNotBootstrapped) {
+		t.Errorf("expected ErrNotBootstrapped, received %v", err)
+	}
+}
+
+type delayedAnnotator struct {
+	kind  contracts.AnnotationType
+	delay time.Duration
+}
+
+func (a delayedAnnotator) Do(ctx context.Context, data []byte) (contracts.Annotation, error) {
+	time.Sleep(a.delay)
+	return contracts.Annotation{Kind: a.kind, Hash: contracts.NoHash}, nil
+}
+
+type recordingStream struct {
+	failingStream
+	published []message.PublishWrapper
+}
+
+func (p *recordingStream) Publish(msg message.PublishWrapper) error {
+	p.published = append(p.published, msg)
+	return nil
+}
+
+func TestSdkAnnotatorExecution(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	annotatorErr := errors.New("annotator failure")
+
+	annotators := []interfaces.Annotator{
+		delayedAnnotator{kind: contracts.AnnotationTPM, delay: 30 * time.Millisecond},
+		failingAnnotator{err: annotatorErr},
+		delayedAnnotator{kind: contracts.AnnotationPKI, delay: 10 * time.Millisecond},
+		delayedAnnotator{kind: contracts.AnnotationTLS},
+	}
+
+	tests := []struct {
+		name          string
+		execution     config.ExecutionInfo
+		annotators    []interfaces.Annotator
+		expectKinds   []contracts.AnnotationType
+		expectPartial bool
+		expectError   bool
+	}{
+		{"sequential fail-fast", config.ExecutionInfo{}, annotators, nil, false, true},
+		{"sequential partial", config.ExecutionInfo{FailurePolicy: contracts.CollectPartial}, annotators,
+			[]contracts.AnnotationType{contracts.AnnotationTPM, contracts.AnnotationPKI, contracts.AnnotationTLS}, true, true},
+		{"concurrent fail-fast", config.ExecutionInfo{Mode: contracts.ConcurrentExecution}, annotators, nil, false, true},
+		{"concurrent partial", config.ExecutionInfo{Mode: contracts.ConcurrentExecution, FailurePolicy: contracts.CollectPartial}, annotators,
+			[]contracts.AnnotationType{contracts.AnnotationTPM, contracts.AnnotationPKI, contracts.AnnotationTLS}, true, true},
+		{"concurrent bounded", config.ExecutionInfo{Mode: contracts.ConcurrentExecution, MaxConcurrency: 2},
+			[]interfaces.Annotator{annotators[0], annotators[2], annotators[3]},
+			[]contracts.AnnotationType{contracts.AnnotationTPM, contracts.AnnotationPKI, contracts.AnnotationTLS}, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &recordingStream{}
+			instance := sdk{
+				annotators: tt.annotators,
+				cfg:        config.SdkInfo{Execution: tt.execution},
+				stream:     stream,
+				logger:     logger,
+			}
+
+			err := instance.TryCreate(context.Background(), []byte("data"))
+			test.CheckError(err, tt.expectError, tt.name, t)
+
+			var partial *PartialAnnotationError
+			if errors.As(err, &partial) != tt.expectPartial {
+				t.Errorf("unexpected PartialAnnotationError result: %v", err)
+			}
+			if tt.expectError && !errors.Is(err, annotatorErr) {
+				t.Errorf("expected annotator failure to be reported, received %v", err)
+			}
+
+			if tt.expectKinds == nil {
+				if len(stream.published) != 0 {
+					t.Errorf("expected nothing to be published, received %d messages", len(stream.published))
+				}
+				return
+			}
+			if len(stream.published) != 1 {
+				t.Fatalf("expected 1 published message, received %d", len(stream.published))
+			}
+			var list contracts.AnnotationList
+			if err := json.Unmarshal(stream.published[0].Content, &list); err != nil {
+				t.Fatalf(err.Error())
+			}
+			if len(list.Items) != len(tt.expectKinds) {
+				t.Fatalf("expected %d annotations, received %d", len(tt.expectKinds), len(list.Items))
+			}
+			for i, kind := range tt.expectKinds {
+				if list.Items[i].Kind != kind {
+					t.Errorf("unexpected annotation order at %d: expected %s received %s", i, kind, list.Items[i].Kind)
+				}
+			}
+		})
+	}
+}
+
+// blockingAnnotator waits for the action to be cancelled and fails with the context's error.
+type blockingAnnotator struct{}
+
+func (a blockingAnnotator) Do(ctx context.Context, data []byte) (contracts.Annotation, error) {
+	<-ctx.Done()
+	return contracts.Annotation{}, ctx.Err()
+}
+
+func TestSdkAnnotatorExecutionCause(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	annotatorErr := errors.New("annotator failure")
+	instance := sdk{
+		annotators: []interfaces.Annotator{blockingAnnotator{}, failingAnnotator{err: annotatorErr}},
+		cfg:        config.SdkInfo{Execution: config.ExecutionInfo{Mode: contracts.ConcurrentExecution}},
+		stream:     &recordingStream{},
+		logger:     logger,
+	}
+
+	// The lower-indexed annotator only fails because the failure of the other cancels it
+	err := instance.TryCreate(context.Background(), []byte("data"))
+	var ae *AnnotatorError
+	if !errors.As(err, &ae) || ae.Index != 1 || !errors.Is(err, annotatorErr) {
+		t.Errorf("expected the failure of annotator 1 to be reported, received %v", err)
+	}
+}
+
+func TestSdkAnnotatorExecutionCancelled(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+	stream := &recordingStream{}
+	instance := sdk{
+		annotators: []interfaces.Annotator{delayedAnnotator{kind: contracts.AnnotationTPM}},
+		cfg:        config.SdkInfo{Execution: config.ExecutionInfo{Mode: contracts.ConcurrentExecution}},
+		stream:     stream,
+		logger:     logger,
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := instance.TryCreate(ctx, []byte("data"))
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, received %v", err)
+	}
+	if len(stream.published) != 0 {
+		t.Errorf("expected nothing to be published, received %d messages", len(stream.published))
+	}
+}
+
+func TestSdkMockStream(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	b, err := os.ReadFile("../test/res/config.json")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var cfg config.SdkInfo
+	err = json.Unmarshal(b, &cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	// Paths in the shared config are relative to packages two levels deep
+	cfg.Signature.PrivateKey.Path = "../test/keys/ed25519/private.key"
+	cfg.Signature.PublicKey.Path = "../test/keys/ed25519/public.key"
+	cfg.Stream.Name = "sdk"
+	cfg.Stream.Config = config.MockStreamConfig{FailEvery: 3}
+
+	tpm, err := factories.NewAnnotator(contracts.AnnotationTPM, cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var streams mock.Streams
+	instance := NewSdk([]interfaces.Annotator{tpm}, cfg, logger, WithStreamHook(streams.Add))
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	if !instance.BootstrapHandler(ctx, &wg) {
+		t.Fatalf("failed to bootstrap")
+	}
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+	stream, ok := streams.Lookup("sdk")
+	if !ok || !stream.Connected() {
+		t.Fatalf("mock stream not connected")
+	}
+
+	tests := []struct {
+		name         string
+		expectAction message.SdkAction
+		expectError  bool
+	}{
+		{"create", message.ActionCreate, false},
+		{"transit", message.ActionTransit, false},
+		{"injected failure", message.ActionPublish, true},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			switch tt.expectAction {
+			case message.ActionCreate:
+				err = instance.TryCreate(ctx, []byte("data"))
+			case message.ActionTransit:
+				err = instance.TryTransit(ctx, []byte("data"))
+			default:
+				err = instance.TryPublish(ctx, []byte("data"))
+			}
+			test.CheckError(err, tt.expectError, tt.name, t)
+			if err != nil {
+				var se *StreamError
+				if !errors.As(err, &se) || !errors.Is(err, mock.ErrInjected) {
+					t.Errorf("unexpected error %v", err)
+				}
+				return
+			}
+
+			waitCtx, waitCancel := context.WithTimeout(ctx, time.Second)
+			defer waitCancel()
+			records, err := stream.WaitForMessages(waitCtx, i+1)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+			r := records[i]
+			if r.Wrapper.Action != tt.expectAction || len(r.Annotations) != 1 ||
+				r.Annotations[0].Kind != contracts.AnnotationTPM || r.Annotations[0].Key != records[0].Annotations[0].Key {
+				t.Errorf("unexpected message %s %v", r.Wrapper.Action, r.Annotations)
+			}
+		})
+	}
+}
+
+func TestSdkBatch(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	b, err := os.ReadFile("../test/res/config.json")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var cfg config.SdkInfo
+	err = json.Unmarshal(b, &cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	cfg.Signature.PrivateKey.Path = "../test/keys/ed25519/private.key"
+	cfg.Signature.PublicKey.Path = "../test/keys/ed25519/public.key"
+	cfg.Stream.Name = "sdk-batch"
+	cfg.Stream.Config = config.MockStreamConfig{}
+	cfg.Batch = config.BatchInfo{Enabled: true, MaxCount: 2, Linger: 60000}
+
+	tpm, err := factories.NewAnnotator(contracts.AnnotationTPM, cfg)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var streams mock.Streams
+	instance := NewSdk([]interfaces.Annotator{tpm}, cfg, logger, WithStreamHook(streams.Add))
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	if !instance.BootstrapHandler(ctx, &wg) {
+		t.Fatalf("failed to bootstrap")
+	}
+	stream, ok := streams.Lookup("sdk-batch")
+	if !ok {
+		t.Fatalf("mock stream not registered")
+	}
+
+	for i := 0; i < 3; i++ {
+		if err = instance.TryCreate(ctx, []byte(fmt.Sprintf("data%d", i))); err != nil {
+			t.Fatalf(err.Error())
+		}
+	}
+	waitCtx, waitCancel := context.WithTimeout(ctx, time.Second)
+	defer waitCancel()
+	if _, err = stream.WaitForMessages(waitCtx, 1); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	// Shutdown publishes the remaining message
+	cancel()
+	wg.Wait()
+	records := stream.Records()
+	if len(records) != 2 {
+		t.Fatalf("expected 2 envelopes, received %d", len(records))
+	}
+	for i, expected := range []int{2, 1} {
+		items, ok := records[i].Wrapper.Batch()
+		if !ok || len(items) != expected {
+			t.Errorf("unexpected envelope %d %v", i, records[i].Wrapper)
+		}
+	}
+
+	// Configuration built in code is validated on bootstrap
+	cfg.Outbox = config.OutboxInfo{Enabled: true, Path: t.TempDir()}
+	if NewSdk([]interfaces.Annotator{tpm}, cfg, logger).BootstrapHandler(context.Background(), &wg) {
+		t.Errorf("expected bootstrap to fail with batch and outbox enabled")
+	}
+}
+
+func TestSdkProofHandler(t *testing.T) {
+	logger := factories.NewLogger(config.LoggingInfo{MinLogLevel: slog.LevelInfo})
+
+	b, err := os.ReadFile("../test/res/config.json")
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	var base config.SdkInfo
+	err = json.Unmarshal(b, &base)
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	base.Signature.PrivateKey.Path = "../test/keys/ed25519/private.key"
+	base.Signature.PublicKey.Path = "../test/keys/ed25519/public.key"
+
+	tests := []struct {
+		name         string
+		configure    func(cfg *config.SdkInfo)
+		expectProofs int
+	}{
+		{"single stream", func(cfg *config.SdkInfo) {
+			cfg.Stream.Config = config.MockStreamConfig{}
+		}, 2},
+		{"fanout", func(cfg *config.SdkInfo) {
+			cfg.Streams = []config.StreamInfo{
+				{Type: contracts.MockStream, Name: "sdk-proof-fanout-1", Config: config.MockStreamConfig{}},
+				{Type: contracts.MockStream, Name: "sdk-proof-fanout-2", Config: config.MockStreamConfig{}},
+			}
+			cfg.Stream = config.StreamInfo{}
+		}, 4},
+		{"async batch", func(cfg *config.SdkInfo) {
+			cfg.Stream.Config = config.MockStreamConfig{}
+			cfg.Async = config.AsyncInfo{Enabled: true}
+			cfg.Batch = config.BatchInfo{Enabled: true, MaxCount: 2, Linger: 60000}
+		}, 1},
+		{"outbox", func(cfg *config.SdkInfo) {
+			cfg.Stream.Config = config.MockStreamConfig{}
+			cfg.Outbox = config.OutboxInfo{Enabled: true, Path: t.TempDir()}
+		}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := base
+			tt.configure(&cfg)
+			tpm, err := factories.NewAnnotator(contracts.AnnotationTPM, cfg)
+			if err != nil {
+				t.Fatalf(err.Error())
+			}
+
+			var mutex sync.Mutex
+			var proofs []message.ConsensusProof
+			annotationIds := make(map[string]bool)
+			handler := func(msg message.PublishWrapper, p []message.ConsensusProof) {
+				mutex.Lock()
+				defer mutex.Unlock()
+				proofs = append(proofs, p...)
+				for _, a := range msg.Annotations() {
+					annotationIds[a.Id.String()] = true
+				}
+			}
+			var streams mock.Streams
+			instance := NewSdk([]interfaces.Annotator{tpm}, cfg, logger, WithProofHandler(handler),
+				WithStreamHook(streams.Add))
+			ctx, cancel := context.WithCancel(context.Background())
+			var wg sync.WaitGroup
+			if !instance.BootstrapHandler(ctx, &wg) {
+				t.Fatalf("failed to bootstrap")
+			}
+
+			for i := 0; i < 2; i++ {
+				if err = instance.TryCreate(ctx, []byte(fmt.Sprintf("data%d", i))); err != nil {
+					t.Fatalf(err.Error())
+				}
+			}
+			// Shutdown waits for messages published in the background
+			cancel()
+			wg.Wait()
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if len(proofs) != tt.expectProofs || len(annotationIds) != 2 {
+				t.Fatalf("unexpected proofs %v for annotations %v", proofs, annotationIds)
+			}
+			for _, proof := range proofs {
+				for _, id := range proof.AnnotationIds {
+					if !annotationIds[id] {
+						t.Errorf("proof %v names unknown annotation %s", proof, id)
+					}
+				}
+			}
+			for _, info := range cfg.Streams {
+				if stream, ok := streams.Lookup(info.Name); !ok || len(stream.Records()) != 2 {
+					t.Errorf("expected stream %s to record both messages", info.Name)
+				}
+			}
+		})
+	}
+}